@@ -15,6 +15,8 @@ import (
 type AddOptions struct {
 	Type          domain.PaneType
 	ExplicitTitle string
+	// Session targets a specific session instead of the current tmux session.
+	Session string
 }
 
 type AddResult struct {
@@ -24,13 +26,20 @@ type AddResult struct {
 }
 
 func (a *App) Add(opts AddOptions) (AddResult, error) {
-	if !a.tmux.InTmux() {
-		return AddResult{}, fmt.Errorf("must be run inside tmux")
-	}
-
-	session, err := a.tmux.CurrentSession()
-	if err != nil {
+	session := opts.Session
+	if session == "" {
+		if !a.tmux.InTmux() {
+			return AddResult{}, fmt.Errorf("must be run inside tmux")
+		}
+		current, err := a.tmux.CurrentSession()
+		if err != nil {
+			return AddResult{}, err
+		}
+		session = current
+	} else if ok, err := a.tmux.HasSession(session); err != nil {
 		return AddResult{}, err
+	} else if !ok {
+		return AddResult{}, fmt.Errorf("session %q not found", session)
 	}
 
 	cwd, err := a.tmux.SessionPath(session)
@@ -60,14 +69,16 @@ func (a *App) Add(opts AddOptions) (AddResult, error) {
 		}
 	}
 
+	policy := a.restartPolicyFor(actualType, config.RestartPolicy{})
+
 	if err := a.tmux.SetPaneTitle(paneID, title); err != nil {
 		return AddResult{}, err
 	}
-	if err := a.launchProvider(paneID, prov); err != nil {
+	if err := a.launchProvider(paneID, withRestartPolicy(prov, policy)); err != nil {
 		return AddResult{}, err
 	}
 
-	if err := a.updateStateForNewPane(session, paneID, actualType, title); err != nil {
+	if err := a.updateStateForNewPane(session, paneID, cwd, paneConfigResult{Type: actualType, Title: title, Command: prov.Command, RestartPolicy: policy}); err != nil {
 		return AddResult{}, err
 	}
 
@@ -82,7 +93,7 @@ func (a *App) applyConfigOverrides(cwd string) error {
 	if cwd == "" {
 		cwd, _ = os.Getwd()
 	}
-	loaded, err := config.LoadAll(cwd)
+	loaded, err := a.loadConfig(cwd)
 	if err != nil {
 		return err
 	}
@@ -90,6 +101,10 @@ func (a *App) applyConfigOverrides(cwd string) error {
 	return nil
 }
 
+func (a *App) loadConfig(cwd string) (*config.Loaded, error) {
+	return config.LoadAllWithProfile(cwd, a.profile)
+}
+
 func (a *App) nextAutoTitle(session string, t domain.PaneType, prov *provider.Provider) (string, error) {
 	count := 0
 
@@ -117,29 +132,29 @@ func (a *App) nextAutoTitle(session string, t domain.PaneType, prov *provider.Pr
 	return fmt.Sprintf("%s%d", prov.TitlePrefix, count+1), nil
 }
 
-func (a *App) updateStateForNewPane(session, paneID string, t domain.PaneType, title string) error {
-	st := a.loadStateOrNew()
-
-	ss, ok := st.Sessions[session]
-	if !ok {
-		path, _ := a.tmux.SessionPath(session)
-		ss = &state.SessionState{
-			Path:      path,
-			CreatedAt: time.Now(),
-			Panes:     []*state.PaneState{},
+func (a *App) updateStateForNewPane(session, paneID, path string, res paneConfigResult) error {
+	return a.updateState(func(st *state.Store) error {
+		ss, ok := st.Sessions[session]
+		if !ok {
+			path, _ := a.tmux.SessionPath(session)
+			ss = &state.SessionState{
+				Path:      path,
+				CreatedAt: time.Now(),
+				Panes:     []*state.PaneState{},
+			}
+			st.Sessions[session] = ss
 		}
-		st.Sessions[session] = ss
-	}
 
-	ss.Panes = append(ss.Panes, &state.PaneState{
-		TmuxID:    paneID,
-		Type:      string(t),
-		Title:     title,
-		CreatedAt: time.Now(),
+		ss.Panes = append(ss.Panes, &state.PaneState{
+			TmuxID:        paneID,
+			Type:          string(res.Type),
+			Title:         res.Title,
+			Path:          path,
+			Command:       res.Command,
+			SpecCommand:   res.SpecCommand,
+			CreatedAt:     time.Now(),
+			RestartPolicy: res.RestartPolicy,
+		})
+		return a.attachServerID(st)
 	})
-
-	if err := a.attachServerID(st); err != nil {
-		return err
-	}
-	return a.state.Save(st)
 }
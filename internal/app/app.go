@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"strings"
 
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/git"
 	"github.com/minghinmatthewlam/agentpane/internal/provider"
 	"github.com/minghinmatthewlam/agentpane/internal/state"
 	"github.com/minghinmatthewlam/agentpane/internal/tmux"
@@ -16,6 +20,14 @@ type App struct {
 	providers *provider.Registry
 	state     *state.StoreFile
 	logger    *log.Logger
+	profile   string
+
+	// providerConfigs holds the merged provider settings of the last loaded
+	// config, used for per-provider restart policies.
+	providerConfigs map[string]config.ProviderConfig
+
+	// git caches repo status for the dashboard, see AnnotateGit.
+	git *git.Reader
 }
 
 func New() (*App, error) {
@@ -34,11 +46,18 @@ func New() (*App, error) {
 		providers: provider.NewRegistry(),
 		state:     state.NewStoreFile(statePath),
 		logger:    log.New(os.Stderr, "agentpane: ", log.LstdFlags),
+		git:       git.NewReader(git.DefaultTTL),
 	}, nil
 }
 
 func (a *App) InTmux() bool { return a.tmux.InTmux() }
 
+// SetProfile selects the config profile used by subsequent commands.
+func (a *App) SetProfile(name string) { a.profile = strings.TrimSpace(name) }
+
+// PaneTypes lists the pane types the provider registry knows about.
+func (a *App) PaneTypes() []domain.PaneType { return a.providers.Types() }
+
 func (a *App) Attach(name string) error {
 	if a.tmux.InTmux() {
 		return a.tmux.SwitchClient(name)
@@ -46,6 +65,48 @@ func (a *App) Attach(name string) error {
 	return a.tmux.AttachSession(name)
 }
 
+type FocusOptions struct {
+	Session string
+	PaneID  string
+	Zoom    bool
+}
+
+// AttachCommand returns the command that attaches to a session from outside
+// tmux, for use by the dashboard which suspends itself while attached.
+func (a *App) AttachCommand(name string) *exec.Cmd {
+	return a.tmux.AttachCommand(name)
+}
+
+// Focus attaches to a session with a specific pane selected, optionally
+// zoomed.
+func (a *App) Focus(opts FocusOptions) error {
+	if err := a.SelectPane(opts.PaneID, opts.Zoom); err != nil {
+		return err
+	}
+	return a.Attach(opts.Session)
+}
+
+// SelectPane makes a pane the active one in its session without attaching.
+func (a *App) SelectPane(paneID string, zoom bool) error {
+	if err := a.tmux.SelectPane(paneID); err != nil {
+		return err
+	}
+	if zoom {
+		return a.tmux.ZoomPane(paneID)
+	}
+	return nil
+}
+
+// UIConfig returns the dashboard key bindings and theme from the config.
+func (a *App) UIConfig() (config.UIConfig, error) {
+	cwd, _ := os.Getwd()
+	loaded, err := a.loadConfig(cwd)
+	if err != nil {
+		return config.UIConfig{}, err
+	}
+	return loaded.Merged.UI, nil
+}
+
 func (a *App) SupportsPopup() (bool, error) {
 	return a.tmux.SupportsPopup()
 }
@@ -103,6 +164,14 @@ func (a *App) CapturePaneContent(paneID string) (string, error) {
 	return a.tmux.CapturePaneContent(paneID)
 }
 
+func (a *App) CapturePaneHistory(paneID string) (string, error) {
+	return a.tmux.CapturePaneHistory(paneID)
+}
+
+// KillSession kills a tmux session and removes it from state.
 func (a *App) KillSession(name string) error {
-	return a.tmux.KillSession(name)
+	if err := a.tmux.KillSession(name); err != nil {
+		return err
+	}
+	return a.forgetSession(name)
 }
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+// PlanAction is what a reconciling template apply does with one pane.
+type PlanAction string
+
+const (
+	PlanKeep   PlanAction = "keep"   // pane matches the template and keeps running
+	PlanAdd    PlanAction = "add"    // template pane that is missing
+	PlanRemove PlanAction = "remove" // pane not in the template, removed with RemoveExtras
+	PlanExtra  PlanAction = "extra"  // pane not in the template, left alone
+)
+
+// PlanStep is one pane in a reconcile plan. PaneID is empty for panes to add.
+type PlanStep struct {
+	Action PlanAction
+	PaneID string
+	Type   domain.PaneType
+	Title  string
+
+	spec config.PaneSpec
+}
+
+// planReconcile matches the current panes against a template. Panes match
+// when type and title agree; template panes without a title then take any
+// remaining pane of their type. Missing panes get the title they would have
+// had from `up`, made unique among the existing titles.
+func (a *App) planReconcile(current []domain.Pane, specs []config.PaneSpec, removeExtras bool) ([]PlanStep, error) {
+	type wanted struct {
+		spec  config.PaneSpec
+		typ   domain.PaneType
+		title string
+		auto  bool
+	}
+
+	counts := map[domain.PaneType]int{}
+	want := make([]wanted, len(specs))
+	for i, spec := range specs {
+		t, err := domain.ParsePaneType(spec.Type)
+		if err != nil {
+			return nil, err
+		}
+		w := wanted{spec: spec, typ: t, title: strings.TrimSpace(spec.Title)}
+		if w.title == "" {
+			counts[t]++
+			w.auto = true
+			w.title = fmt.Sprintf("%s%d", a.titlePrefix(t), counts[t])
+		}
+		want[i] = w
+	}
+
+	matched := make([]int, len(want)) // index+1 into current, 0 when unmatched
+	used := make([]bool, len(current))
+	match := func(i int, ok func(p domain.Pane) bool) {
+		for j, p := range current {
+			if !used[j] && p.Type == want[i].typ && ok(p) {
+				used[j] = true
+				matched[i] = j + 1
+				return
+			}
+		}
+	}
+	for i := range want {
+		match(i, func(p domain.Pane) bool { return p.Title == want[i].title })
+	}
+	for i := range want {
+		if matched[i] == 0 && want[i].auto {
+			match(i, func(domain.Pane) bool { return true })
+		}
+	}
+
+	// Titles that are taken: those of existing panes and the ones later
+	// specs ask for by name.
+	titles := map[string]bool{}
+	for _, p := range current {
+		titles[p.Title] = true
+	}
+	for i, w := range want {
+		if matched[i] == 0 && !w.auto {
+			titles[w.title] = true
+		}
+	}
+
+	var steps []PlanStep
+	for i, w := range want {
+		if matched[i] != 0 {
+			p := current[matched[i]-1]
+			steps = append(steps, PlanStep{Action: PlanKeep, PaneID: p.ID, Type: p.Type, Title: p.Title})
+			continue
+		}
+		// New auto-titled panes take the lowest free index, in template order.
+		title := w.title
+		if w.auto {
+			for n := 1; ; n++ {
+				title = fmt.Sprintf("%s%d", a.titlePrefix(w.typ), n)
+				if !titles[title] {
+					break
+				}
+			}
+		}
+		titles[title] = true
+		spec := w.spec
+		spec.Title = title
+		steps = append(steps, PlanStep{Action: PlanAdd, Type: w.typ, Title: title, spec: spec})
+	}
+
+	extra := PlanExtra
+	if removeExtras {
+		extra = PlanRemove
+	}
+	for j, p := range current {
+		if !used[j] {
+			steps = append(steps, PlanStep{Action: extra, PaneID: p.ID, Type: p.Type, Title: p.Title})
+		}
+	}
+	return steps, nil
+}
+
+func (a *App) titlePrefix(t domain.PaneType) string {
+	if prov, ok := a.providers.Get(t); ok {
+		return prov.TitlePrefix
+	}
+	return string(t) + "-"
+}
+
+// applyPlan adds and removes panes as planned. Panes to keep are not
+// touched.
+func (a *App) applyPlan(session, sessionPath, layout string, steps []PlanStep) error {
+	changed := false
+	for i, step := range steps {
+		switch step.Action {
+		case PlanAdd:
+			paneID, err := a.tmux.SplitPane(session, sessionPath)
+			if err != nil {
+				return err
+			}
+			res, err := a.configurePaneSpec(paneID, step.spec, map[domain.PaneType]int{})
+			if err != nil {
+				return err
+			}
+			if err := a.updateStateForNewPane(session, paneID, sessionPath, res); err != nil {
+				return err
+			}
+			steps[i].PaneID = paneID
+			changed = true
+		case PlanRemove:
+			if err := a.ClosePane(step.PaneID); err != nil {
+				return err
+			}
+			changed = true
+		}
+	}
+
+	if changed {
+		a.selectLayout(session, layout, countPanes(steps))
+	}
+	return nil
+}
+
+// countPanes is the number of panes left once a plan is applied.
+func countPanes(steps []PlanStep) int {
+	n := 0
+	for _, s := range steps {
+		if s.Action != PlanRemove {
+			n++
+		}
+	}
+	return n
+}
+
+func (a *App) sessionPanes(session string) ([]domain.Pane, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshot.Sessions {
+		if s.Name == session {
+			return s.Panes, nil
+		}
+	}
+	return nil, fmt.Errorf("session %q not found", session)
+}
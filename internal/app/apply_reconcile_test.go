@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+)
+
+func TestPlanReconcile(t *testing.T) {
+	a := &App{providers: provider.NewRegistry()}
+	pane := func(id string, typ domain.PaneType, title string) domain.Pane {
+		return domain.Pane{ID: id, Type: typ, Title: title}
+	}
+
+	cases := []struct {
+		name         string
+		current      []domain.Pane
+		specs        []config.PaneSpec
+		removeExtras bool
+		want         []string
+	}{
+		{
+			name:    "match by title",
+			current: []domain.Pane{pane("%1", domain.PaneClaude, "claude-1"), pane("%2", domain.PaneClaude, "reviewer")},
+			specs:   []config.PaneSpec{{Type: "claude", Title: "reviewer"}},
+			want:    []string{"keep %2 claude/reviewer", "extra %1 claude/claude-1"},
+		},
+		{
+			name:    "titled spec does not take a pane with another title",
+			current: []domain.Pane{pane("%1", domain.PaneClaude, "scratch")},
+			specs:   []config.PaneSpec{{Type: "claude", Title: "reviewer"}},
+			want:    []string{"add - claude/reviewer", "extra %1 claude/scratch"},
+		},
+		{
+			name:    "untitled specs take remaining panes of their type",
+			current: []domain.Pane{pane("%1", domain.PaneClaude, "my-agent"), pane("%2", domain.PaneCodex, "codex-1"), pane("%3", domain.PaneShell, "logs")},
+			specs:   []config.PaneSpec{{Type: "claude"}, {Type: "codex"}, {Type: "codex"}},
+			want:    []string{"keep %1 claude/my-agent", "keep %2 codex/codex-1", "add - codex/codex-2", "extra %3 shell/logs"},
+		},
+		{
+			name:    "auto titles are unique among existing panes",
+			current: []domain.Pane{pane("%1", domain.PaneShell, "claude-1")},
+			specs:   []config.PaneSpec{{Type: "claude"}, {Type: "claude"}},
+			want:    []string{"add - claude/claude-2", "add - claude/claude-3", "extra %1 shell/claude-1"},
+		},
+		{
+			name:    "auto titles take the lowest free index",
+			current: []domain.Pane{pane("%1", domain.PaneClaude, "reviewer")},
+			specs:   []config.PaneSpec{{Type: "claude"}, {Type: "claude"}, {Type: "claude", Title: "claude-1"}},
+			want:    []string{"keep %1 claude/reviewer", "add - claude/claude-2", "add - claude/claude-1"},
+		},
+		{
+			name:         "extras removed with remove-extras",
+			current:      []domain.Pane{pane("%1", domain.PaneClaude, "claude-1"), pane("%2", domain.PaneShell, "shell-1")},
+			specs:        []config.PaneSpec{{Type: "claude"}},
+			removeExtras: true,
+			want:         []string{"keep %1 claude/claude-1", "remove %2 shell/shell-1"},
+		},
+	}
+	for _, c := range cases {
+		steps, err := a.planReconcile(c.current, c.specs, c.removeExtras)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		var got []string
+		for _, s := range steps {
+			id := s.PaneID
+			if id == "" {
+				id = "-"
+			}
+			got = append(got, fmt.Sprintf("%s %s %s/%s", s.Action, id, s.Type, s.Title))
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s:\n got %q\nwant %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPlanReconcileAddsSpecWithTitle(t *testing.T) {
+	a := &App{providers: provider.NewRegistry()}
+	steps, err := a.planReconcile(nil, []config.PaneSpec{{Type: "codex", Command: "codex --model o3"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 1 || steps[0].spec.Title != "codex-1" || steps[0].spec.Command != "codex --model o3" {
+		t.Fatalf("unexpected plan %+v", steps)
+	}
+}
+
+func TestPlanReconcileRejectsUnknownType(t *testing.T) {
+	a := &App{providers: provider.NewRegistry()}
+	if _, err := a.planReconcile(nil, []config.PaneSpec{{Type: "robot"}}, false); err == nil {
+		t.Fatal("expected an error for an unknown pane type")
+	}
+}
@@ -14,12 +14,21 @@ type ApplyTemplateOptions struct {
 	Session  string
 	Template string
 	Force    bool
+	// Reconcile keeps panes that match the template running and only adds
+	// the missing ones, instead of rebuilding the session.
+	Reconcile bool
+	// RemoveExtras also closes panes not in the template when reconciling.
+	RemoveExtras bool
+	// DryRun computes the reconcile plan without changing anything.
+	DryRun bool
 }
 
 type ApplyTemplateResult struct {
 	Session  string
 	Template string
 	Panes    int
+	// Plan lists what a reconcile did, or would do for a dry run.
+	Plan []PlanStep
 }
 
 func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, error) {
@@ -40,7 +49,7 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 		sessionPath = ""
 	}
 
-	loaded, err := config.LoadAll(sessionPath)
+	loaded, err := a.loadConfig(sessionPath)
 	if err != nil {
 		return ApplyTemplateResult{}, err
 	}
@@ -51,6 +60,10 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 		return ApplyTemplateResult{}, fmt.Errorf("unknown template %q", opts.Template)
 	}
 
+	if opts.Reconcile {
+		return a.reconcileTemplate(session, sessionPath, loaded.Merged.Env, tmpl, opts)
+	}
+
 	panes, err := a.tmux.ListPanes(session)
 	if err != nil {
 		return ApplyTemplateResult{}, err
@@ -59,10 +72,7 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 		return ApplyTemplateResult{}, fmt.Errorf("session has %d panes; use --force to apply template", len(panes))
 	}
 
-	if err := a.tmux.SetOption(session, "pane-border-status", "top"); err != nil {
-		return ApplyTemplateResult{}, err
-	}
-	if err := a.tmux.SetOption(session, "pane-border-format", " #{pane_title} "); err != nil {
+	if err := a.prepareTemplateSession(session, loaded.Merged.Env); err != nil {
 		return ApplyTemplateResult{}, err
 	}
 
@@ -87,10 +97,14 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 		return ApplyTemplateResult{}, err
 	}
 	paneStates = append(paneStates, &state.PaneState{
-		TmuxID:    firstPaneID,
-		Type:      string(firstRes.Type),
-		Title:     firstRes.Title,
-		CreatedAt: time.Now(),
+		TmuxID:        firstPaneID,
+		Type:          string(firstRes.Type),
+		Title:         firstRes.Title,
+		Path:          sessionPath,
+		Command:       firstRes.Command,
+		SpecCommand:   firstRes.SpecCommand,
+		CreatedAt:     time.Now(),
+		RestartPolicy: firstRes.RestartPolicy,
 	})
 
 	for i := 1; i < len(tmpl.Panes); i++ {
@@ -110,20 +124,20 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 			return ApplyTemplateResult{}, err
 		}
 		paneStates = append(paneStates, &state.PaneState{
-			TmuxID:    newPaneID,
-			Type:      string(res.Type),
-			Title:     res.Title,
-			CreatedAt: time.Now(),
+			TmuxID:        newPaneID,
+			Type:          string(res.Type),
+			Title:         res.Title,
+			Path:          sessionPath,
+			Command:       res.Command,
+			SpecCommand:   res.SpecCommand,
+			CreatedAt:     time.Now(),
+			RestartPolicy: res.RestartPolicy,
 		})
 	}
 
-	layout := "tiled"
-	if len(tmpl.Panes) == 2 {
-		layout = "even-horizontal"
-	}
-	_ = a.tmux.SelectLayout(session, layout)
+	a.selectLayout(session, tmpl.Layout, len(tmpl.Panes))
 
-	if err := a.replaceSessionState(session, sessionPath, paneStates); err != nil {
+	if err := a.replaceSessionState(session, sessionPath, loaded.Profile, paneStates); err != nil {
 		return ApplyTemplateResult{}, err
 	}
 
@@ -134,22 +148,89 @@ func (a *App) ApplyTemplate(opts ApplyTemplateOptions) (ApplyTemplateResult, err
 	}, nil
 }
 
-func (a *App) replaceSessionState(session, path string, panes []*state.PaneState) error {
-	st := a.loadStateOrNew()
-	if err := a.attachServerID(st); err != nil {
-		return err
+func (a *App) reconcileTemplate(session, sessionPath string, env map[string]string, tmpl config.Template, opts ApplyTemplateOptions) (ApplyTemplateResult, error) {
+	current, err := a.sessionPanes(session)
+	if err != nil {
+		return ApplyTemplateResult{}, err
+	}
+	steps, err := a.planReconcile(current, tmpl.Panes, opts.RemoveExtras)
+	if err != nil {
+		return ApplyTemplateResult{}, err
+	}
+	result := ApplyTemplateResult{
+		Session:  session,
+		Template: opts.Template,
+		Panes:    countPanes(steps),
+		Plan:     steps,
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := a.prepareTemplateSession(session, env); err != nil {
+		return ApplyTemplateResult{}, err
 	}
+	if err := a.applyPlan(session, sessionPath, tmpl.Layout, steps); err != nil {
+		return ApplyTemplateResult{}, err
+	}
+	return result, nil
+}
 
-	st.Sessions[session] = &state.SessionState{
-		Path:      path,
-		CreatedAt: time.Now(),
-		Panes:     panes,
+// prepareTemplateSession sets the pane border options and config env on a
+// session a template is applied to.
+func (a *App) prepareTemplateSession(session string, env map[string]string) error {
+	if err := a.tmux.SetOption(session, "pane-border-status", "top"); err != nil {
+		return err
 	}
+	if err := a.tmux.SetOption(session, "pane-border-format", " #{pane_title} "); err != nil {
+		return err
+	}
+	for k, v := range env {
+		if err := a.tmux.SetSessionEnv(session, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Ensure deterministic ordering for stability
-	sort.Slice(st.Sessions[session].Panes, func(i, j int) bool {
-		return st.Sessions[session].Panes[i].TmuxID < st.Sessions[session].Panes[j].TmuxID
+// replaceSessionState records a session's new panes. The session's tags and
+// note, and those of panes that survived, are kept.
+func (a *App) replaceSessionState(session, path, profile string, panes []*state.PaneState) error {
+	return a.updateState(func(st *state.Store) error {
+		if err := a.attachServerID(st); err != nil {
+			return err
+		}
+
+		ss := &state.SessionState{
+			Path:      path,
+			Profile:   profile,
+			CreatedAt: time.Now(),
+			Panes:     panes,
+		}
+		if prev := st.Sessions[session]; prev != nil {
+			carryMetadata(ss, prev)
+		}
+		st.Sessions[session] = ss
+
+		// Ensure deterministic ordering for stability
+		sort.Slice(ss.Panes, func(i, j int) bool {
+			return ss.Panes[i].TmuxID < ss.Panes[j].TmuxID
+		})
+		return nil
 	})
+}
 
-	return a.state.Save(st)
+// carryMetadata copies the tags and note of prev, and of its panes, onto the
+// matching panes of ss.
+func carryMetadata(ss, prev *state.SessionState) {
+	ss.Tags, ss.Note = prev.Tags, prev.Note
+	old := make(map[string]*state.PaneState, len(prev.Panes))
+	for _, p := range prev.Panes {
+		old[p.TmuxID] = p
+	}
+	for _, p := range ss.Panes {
+		if o, ok := old[p.TmuxID]; ok {
+			p.Tags, p.Note = o.Tags, o.Note
+		}
+	}
 }
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+func TestCarryMetadata(t *testing.T) {
+	prev := &state.SessionState{
+		Tags: []string{"infra"},
+		Note: "migrating the db",
+		Panes: []*state.PaneState{
+			{TmuxID: "%1", Title: "claude-1", Tags: []string{"review"}, Note: "on the schema"},
+			{TmuxID: "%2", Title: "codex-1", Tags: []string{"gone"}},
+		},
+	}
+	ss := &state.SessionState{
+		Panes: []*state.PaneState{
+			{TmuxID: "%1", Title: "codex-1"},
+			{TmuxID: "%5", Title: "shell-1"},
+		},
+	}
+	carryMetadata(ss, prev)
+
+	if len(ss.Tags) != 1 || ss.Tags[0] != "infra" || ss.Note != "migrating the db" {
+		t.Fatalf("expected session metadata kept, got %#v", ss)
+	}
+	if p := ss.Panes[0]; len(p.Tags) != 1 || p.Tags[0] != "review" || p.Note != "on the schema" {
+		t.Fatalf("expected surviving pane metadata kept, got %#v", p)
+	}
+	if p := ss.Panes[1]; p.Tags != nil || p.Note != "" {
+		t.Fatalf("expected new pane without metadata, got %#v", p)
+	}
+}
@@ -0,0 +1,307 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/bundle"
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+type ExportOptions struct {
+	Session string
+	// Transcripts includes each pane's scrollback, with secrets masked.
+	Transcripts bool
+}
+
+// ExportSession describes a running session as a portable bundle. Provider
+// commands, env values and transcripts are redacted.
+func (a *App) ExportSession(opts ExportOptions) (*bundle.Bundle, error) {
+	tmpl, err := a.captureSession(opts.Session)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tmpl.Panes {
+		tmpl.Panes[i].Command = bundle.RedactText(tmpl.Panes[i].Command)
+	}
+
+	sessionPath, _ := a.tmux.SessionPath(opts.Session)
+	loaded, err := a.loadConfig(sessionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bundle.Bundle{
+		Session:    opts.Session,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Repo:       bundle.Repo{Path: sessionPath, Remote: a.git.Remote(sessionPath)},
+		Template:   tmpl,
+		Env:        bundle.RedactEnv(loaded.RawEnv),
+	}
+	if ss, ok := a.loadStateOrNew().Sessions[opts.Session]; ok {
+		b.Profile = ss.Profile
+	}
+	if st, ok := a.git.Status(sessionPath); ok {
+		b.Repo.Branch = st.Branch
+		b.Repo.Head = st.Head
+	}
+
+	if opts.Transcripts {
+		transcripts, err := a.captureTranscripts(opts.Session)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transcripts {
+			t.Content = bundle.RedactText(t.Content)
+			b.Transcripts = append(b.Transcripts, t)
+		}
+	}
+	return b, nil
+}
+
+// captureTranscripts returns each pane's scrollback as plain text.
+func (a *App) captureTranscripts(session string) ([]bundle.Transcript, error) {
+	panes, err := a.sessionPanes(session)
+	if err != nil {
+		return nil, err
+	}
+	var out []bundle.Transcript
+	for _, p := range panes {
+		content, err := a.tmux.CapturePaneHistory(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bundle.Transcript{
+			Title:   p.Title,
+			Content: strings.TrimRight(ansi.Strip(content), " \n") + "\n",
+		})
+	}
+	return out, nil
+}
+
+type ImportOptions struct {
+	Bundle *bundle.Bundle
+	// Path is the local repo directory. When empty, the exported path is used
+	// if it exists, otherwise the user is asked.
+	Path string
+	// Name overrides the bundle's session name.
+	Name   string
+	Detach bool
+	// Trust runs the bundle's commands and env without showing them and
+	// asking first.
+	Trust bool
+}
+
+type ImportResult struct {
+	UpResult
+	Path string
+	// TranscriptsDir is where bundled transcripts were written, if any.
+	TranscriptsDir string
+}
+
+// ImportBundle recreates a bundled session in a local repo through the same
+// path as `up`.
+func (a *App) ImportBundle(opts ImportOptions) (ImportResult, error) {
+	b := opts.Bundle
+	path, err := a.importPath(b, opts.Path)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	loaded, err := a.loadConfig(path)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	a.applyProviderOverrides(loaded.Merged)
+	if b.Profile != "" && loaded.Profile == "" {
+		loaded.Profile = b.Profile
+	}
+
+	name, err := a.resolveSessionName(path, opts.Name, b.Session, true)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if exists, err := a.tmux.HasSession(name); err != nil {
+		return ImportResult{}, err
+	} else if exists {
+		return ImportResult{}, fmt.Errorf("session %q already exists; pass a different name", name)
+	}
+
+	panes, warnings := importPanes(b.Template.Panes, config.NewExpandContext(path, true))
+	if !opts.Trust {
+		if err := confirmImport(importPreview(panes, b.Env)); err != nil {
+			return ImportResult{}, err
+		}
+	}
+	for k, v := range b.Env {
+		loaded.Merged.Env[k] = os.ExpandEnv(v)
+	}
+
+	if b.Repo.Head != "" {
+		if st, ok := a.git.Status(path); ok && st.Head != b.Repo.Head {
+			warnings = append(warnings, fmt.Sprintf("bundle was exported at %s (%s); %s is at %s (%s)",
+				b.Repo.Head, b.Repo.Branch, path, st.Head, st.Branch))
+		}
+	}
+
+	created, err := a.createSessionFromPanes(name, path, panes, b.Template.Layout, loaded)
+	if err != nil {
+		// Don't leave a half-built session behind.
+		_ = a.KillSession(name)
+		return ImportResult{}, err
+	}
+
+	var transcripts string
+	if len(b.Transcripts) > 0 {
+		if transcripts, err = writeTranscripts(name, b.Transcripts); err != nil {
+			return ImportResult{}, err
+		}
+	}
+	result, err := a.finishCreatedSession(name, opts.Detach, append(warnings, created...))
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return ImportResult{UpResult: result, Path: path, TranscriptsDir: transcripts}, nil
+}
+
+// importPanes drops commands that still contain a redacted secret, so those
+// panes start with their provider's default command instead, and expands
+// placeholders such as {{repo}} for the import directory.
+func importPanes(specs []config.PaneSpec, ctx config.ExpandContext) ([]config.PaneSpec, []string) {
+	panes := make([]config.PaneSpec, len(specs))
+	var warnings []string
+	for i, p := range specs {
+		if strings.Contains(p.Command, bundle.Placeholder) {
+			warnings = append(warnings, fmt.Sprintf("pane %s had a secret removed from its command; started it with the default %s command", orType(p.Title, p.Type), p.Type))
+			p.Command = ""
+		}
+		panes[i] = p
+	}
+	return config.ExpandPanes(panes, ctx), warnings
+}
+
+// importPreview lists the commands and env a bundle would run, one per line.
+// It is empty when the bundle only starts default provider commands.
+func importPreview(panes []config.PaneSpec, env map[string]string) []string {
+	var lines []string
+	for _, p := range panes {
+		if p.Command != "" {
+			lines = append(lines, fmt.Sprintf("pane %s (%s): %s", orType(p.Title, p.Type), p.Type, p.Command))
+		}
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("env %s=%s", k, env[k]))
+	}
+	return lines
+}
+
+// confirmImport shows what a bundle would run and asks before running it.
+// Without a terminal the import is refused unless --trust was passed.
+func confirmImport(preview []string) error {
+	if len(preview) == 0 {
+		return nil
+	}
+	if !stdinIsTTY() {
+		return fmt.Errorf("bundle sets custom commands or env; review it and pass --trust to import it")
+	}
+	fmt.Fprintln(os.Stderr, "This bundle will run:")
+	for _, line := range preview {
+		fmt.Fprintln(os.Stderr, "  "+line)
+	}
+	fmt.Fprint(os.Stderr, "Continue? [y/N] ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("import cancelled")
+}
+
+func orType(title, paneType string) string {
+	if title != "" {
+		return title
+	}
+	return paneType
+}
+
+// importPath picks the local directory for a bundle: the explicit path, the
+// exported path if it exists here, or one entered at a prompt.
+func (a *App) importPath(b *bundle.Bundle, explicit string) (string, error) {
+	path := explicit
+	if path == "" {
+		if info, err := os.Stat(b.Repo.Path); err == nil && info.IsDir() {
+			return b.Repo.Path, nil
+		}
+		if !stdinIsTTY() {
+			return "", fmt.Errorf("%s does not exist here; use --path to choose the repo directory", b.Repo.Path)
+		}
+		from := b.Repo.Path
+		if b.Repo.Remote != "" {
+			from = fmt.Sprintf("%s (%s)", b.Repo.Remote, b.Repo.Path)
+		}
+		fmt.Fprintf(os.Stderr, "Bundle was exported from %s\nLocal repo directory: ", from)
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		path = strings.TrimSpace(line)
+		if path == "" {
+			return "", fmt.Errorf("no repo directory given")
+		}
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", abs)
+	}
+	return abs, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// writeTranscripts stores transcripts under the transcripts directory, one
+// file per pane, and returns the directory.
+func writeTranscripts(session string, transcripts []bundle.Transcript) (string, error) {
+	base, err := state.TranscriptsDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, session+"-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	for i, t := range transcripts {
+		name := fmt.Sprintf("%02d-%s.txt", i+1, unsafeFileChars.ReplaceAllString(t.Title, "_"))
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(t.Content), 0o644); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
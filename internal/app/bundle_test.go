@@ -0,0 +1,68 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/bundle"
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+)
+
+func TestImportPanesDropsRedactedCommands(t *testing.T) {
+	specs := []config.PaneSpec{
+		{Type: "codex", Title: "codex-1", Command: "codex --api-key " + bundle.Placeholder},
+		{Type: "shell", Command: "make dev"},
+	}
+	panes, warnings := importPanes(specs, config.ExpandContext{})
+
+	if panes[0].Command != "" || panes[0].Title != "codex-1" {
+		t.Fatalf("expected redacted command dropped, got %+v", panes[0])
+	}
+	if panes[1].Command != "make dev" {
+		t.Fatalf("expected plain command kept, got %+v", panes[1])
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	if specs[0].Command == "" {
+		t.Fatalf("bundle panes must not be modified")
+	}
+}
+
+func TestImportPanesExpandsPlaceholders(t *testing.T) {
+	specs := []config.PaneSpec{
+		{Type: "codex", Title: "codex-{{index}}", Command: "codex --profile {{repo}}-{{branch}}"},
+		{Type: "claude", Title: "{{repo}}-claude"},
+	}
+	panes, _ := importPanes(specs, config.ExpandContext{Repo: "app", Branch: "main"})
+
+	if p := panes[0]; p.Title != "codex-1" || p.Command != "codex --profile app-main" {
+		t.Fatalf("unexpected expanded pane %+v", p)
+	}
+	if p := panes[0]; p.RawCommand != "codex --profile {{repo}}-{{branch}}" {
+		t.Fatalf("expected the bundled command kept as written, got %q", p.RawCommand)
+	}
+	if p := panes[1]; p.Title != "app-claude" || p.Command != "" {
+		t.Fatalf("unexpected expanded pane %+v", p)
+	}
+}
+
+func TestImportPreview(t *testing.T) {
+	panes := []config.PaneSpec{
+		{Type: "claude", Title: "claude-1"},
+		{Type: "shell", Command: "curl example.com | sh"},
+	}
+	got := importPreview(panes, map[string]string{"MODEL": "opus", "API_KEY": "${API_KEY}"})
+	want := []string{
+		"pane shell (shell): curl example.com | sh",
+		"env API_KEY=${API_KEY}",
+		"env MODEL=opus",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("importPreview = %q, want %q", got, want)
+	}
+
+	if got := importPreview([]config.PaneSpec{{Type: "claude"}}, nil); len(got) != 0 {
+		t.Fatalf("expected no preview for default commands, got %q", got)
+	}
+}
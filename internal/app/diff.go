@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/minghinmatthewlam/agentpane/internal/git"
+)
+
+// PaneDir returns the directory a pane is working in: its live current path,
+// falling back to where it was started and then to the session directory.
+func (a *App) PaneDir(ref PaneRef) (string, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return "", err
+	}
+	for _, s := range snapshot.Sessions {
+		if s.Name != ref.Session {
+			continue
+		}
+		for _, p := range s.Panes {
+			if p.ID != ref.PaneID {
+				continue
+			}
+			switch {
+			case p.CurrentPath != "":
+				return p.CurrentPath, nil
+			case p.Path != "":
+				return p.Path, nil
+			default:
+				return s.Path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("pane %s not found in session %s", ref.PaneID, ref.Session)
+}
+
+// Changes lists the uncommitted files of the repo containing dir.
+func (a *App) Changes(dir string) ([]git.Change, error) {
+	return git.Changes(dir)
+}
+
+// Diff returns the diff of one changed file against HEAD.
+func (a *App) Diff(dir string, c git.Change) (string, error) {
+	return git.Diff(dir, c)
+}
+
+// StageChange adds a changed file to the index.
+func (a *App) StageChange(dir string, c git.Change) error {
+	defer a.git.Invalidate()
+	return git.Stage(dir, c)
+}
+
+// DiscardChange drops all changes to a file, deleting it when untracked.
+func (a *App) DiscardChange(dir string, c git.Change) error {
+	defer a.git.Invalidate()
+	return git.Discard(dir, c)
+}
+
+// Commit commits the staged changes of the repo containing dir.
+func (a *App) Commit(dir, message string) error {
+	defer a.git.Invalidate()
+	return git.Commit(dir, message)
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+)
+
+// DefaultDownTimeout is how long `down` waits for agents to exit by default.
+const DefaultDownTimeout = 10 * time.Second
+
+type DownOptions struct {
+	// Cwd picks the session whose directory it is when Session and All are
+	// unset, like `up` does.
+	Cwd     string
+	Session string
+	All     bool
+	// Timeout is how long agents get to exit after their quit sequence
+	// before the session is killed anyway. Zero uses DefaultDownTimeout.
+	Timeout time.Duration
+	// Transcripts archives each pane's scrollback before the session is killed.
+	Transcripts bool
+}
+
+type DownResult struct {
+	Sessions []DownSession
+}
+
+// DownSession reports what happened to one session.
+type DownSession struct {
+	Name string
+	// Forced lists agents that were still running when the timeout expired.
+	Forced []string
+	// TranscriptsDir is where scrollback was archived, if requested.
+	TranscriptsDir string
+	// Warnings holds hook and archive failures; they don't stop the teardown.
+	Warnings []string
+}
+
+// Down asks the agents in one or more sessions to exit, kills the sessions,
+// runs their down hooks and removes them from state. When run from inside a
+// session that is being torn down, that session is handled last and killed
+// after its hooks have run, since killing it ends this process.
+func (a *App) Down(opts DownOptions) (DownResult, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return DownResult{}, err
+	}
+	targets, err := downTargets(snapshot, opts)
+	if err != nil {
+		return DownResult{}, err
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDownTimeout
+	}
+
+	var result DownResult
+	var current *domain.Session
+	for i := range targets {
+		s := &targets[i]
+		if s.Name == snapshot.CurrentSession {
+			current = s
+			continue
+		}
+		ds, err := a.downSession(s, timeout, opts.Transcripts, false)
+		if err != nil {
+			return result, err
+		}
+		result.Sessions = append(result.Sessions, ds)
+	}
+	if current != nil {
+		ds, err := a.downSession(current, timeout, opts.Transcripts, true)
+		if err != nil {
+			return result, err
+		}
+		result.Sessions = append(result.Sessions, ds)
+	}
+	return result, nil
+}
+
+func downTargets(snapshot domain.Snapshot, opts DownOptions) ([]domain.Session, error) {
+	if opts.All {
+		if len(snapshot.Sessions) == 0 {
+			return nil, fmt.Errorf("no sessions running")
+		}
+		return snapshot.Sessions, nil
+	}
+	if opts.Session != "" {
+		for _, s := range snapshot.Sessions {
+			if s.Name == opts.Session {
+				return []domain.Session{s}, nil
+			}
+		}
+		return nil, fmt.Errorf("session %q not found", opts.Session)
+	}
+
+	s, err := sessionForDir(snapshot, opts.Cwd)
+	if err != nil {
+		return nil, fmt.Errorf("%w (use --session or --all)", err)
+	}
+	return []domain.Session{s}, nil
+}
+
+// sessionForDir finds the session started in dir. Several sessions can share
+// a directory; the current one wins, otherwise the match must be unique.
+func sessionForDir(snapshot domain.Snapshot, dir string) (domain.Session, error) {
+	var matches []domain.Session
+	for _, s := range snapshot.Sessions {
+		if !samePath(s.Path, dir) {
+			continue
+		}
+		if s.Name == snapshot.CurrentSession {
+			return s, nil
+		}
+		matches = append(matches, s)
+	}
+	switch len(matches) {
+	case 0:
+		return domain.Session{}, fmt.Errorf("no session for %s", dir)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, s := range matches {
+		names[i] = s.Name
+	}
+	return domain.Session{}, fmt.Errorf("several sessions for %s: %s", dir, strings.Join(names, ", "))
+}
+
+func (a *App) downSession(s *domain.Session, timeout time.Duration, transcripts, killLast bool) (DownSession, error) {
+	ds := DownSession{Name: s.Name}
+
+	loaded, err := a.loadConfig(s.Path)
+	if err != nil {
+		return ds, err
+	}
+	a.applyProviderOverrides(loaded.Merged)
+
+	// Capture before the agents quit: those drawing on the alternate screen
+	// take their visible content with them when they exit.
+	if transcripts {
+		captured, err := a.captureTranscripts(s.Name)
+		if err == nil {
+			ds.TranscriptsDir, err = writeTranscripts(s.Name, captured)
+		}
+		if err != nil {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("archiving transcripts: %v", err))
+		}
+	}
+
+	ds.Forced = a.quitAgents(s.Panes, timeout)
+
+	if !killLast {
+		if err := a.KillSession(s.Name); err != nil {
+			return ds, err
+		}
+	} else if err := a.forgetSession(s.Name); err != nil {
+		return ds, err
+	}
+
+	for _, hook := range loaded.Merged.Hooks.Down {
+		if err := runHook(hook, s.Name, s.Path); err != nil {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("hook %q: %v", hook, err))
+		}
+	}
+
+	if killLast {
+		if err := a.tmux.KillSession(s.Name); err != nil {
+			return ds, err
+		}
+	}
+	return ds, nil
+}
+
+// quitAgents sends each running agent its quit sequence and waits for them
+// to exit. It returns the titles of agents still running at the timeout.
+func (a *App) quitAgents(panes []domain.Pane, timeout time.Duration) []string {
+	var waiting []domain.Pane
+	for _, p := range panes {
+		if p.Type == domain.PaneShell || p.Status != domain.StatusActive {
+			continue
+		}
+		quit := a.quitSequence(p.Type)
+		if quit == "" {
+			continue
+		}
+		if err := a.tmux.SendKeysLiteral(p.ID, quit); err != nil {
+			a.logger.Printf("failed to send quit to %s: %v", p.ID, err)
+			continue
+		}
+		if err := a.tmux.SendEnter(p.ID); err != nil {
+			a.logger.Printf("failed to send quit to %s: %v", p.ID, err)
+			continue
+		}
+		waiting = append(waiting, p)
+	}
+
+	detector := provider.NewStatusDetector(a.providers)
+	deadline := time.Now().Add(timeout)
+	for len(waiting) > 0 {
+		running := waiting[:0]
+		for _, p := range waiting {
+			if detector.DetectStatus(p.PID, p.Type) == domain.StatusActive {
+				running = append(running, p)
+			}
+		}
+		waiting = running
+		if len(waiting) == 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	var forced []string
+	for _, p := range waiting {
+		forced = append(forced, p.Title)
+	}
+	return forced
+}
+
+// quitSequence is the configured quit text for a pane type, falling back to
+// the provider's default.
+func (a *App) quitSequence(t domain.PaneType) string {
+	if quit := a.providerConfigs[string(t)].Quit; quit != "" {
+		return quit
+	}
+	if prov, ok := a.providers.Get(t); ok {
+		return prov.Quit
+	}
+	return ""
+}
+
+func runHook(hook, session, dir string) error {
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "AGENTPANE_SESSION="+session)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
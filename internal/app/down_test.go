@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+func TestSessionForDir(t *testing.T) {
+	sessions := []domain.Session{
+		{Name: "app", Path: "/src/app"},
+		{Name: "app-2", Path: "/src/app/"},
+		{Name: "api", Path: "/src/api"},
+	}
+
+	cases := []struct {
+		name    string
+		current string
+		dir     string
+		want    string
+		wantErr string
+	}{
+		{name: "unique", dir: "/src/api", want: "api"},
+		{name: "unclean path", dir: "/src/api/.", want: "api"},
+		{name: "prefer current", current: "app-2", dir: "/src/app", want: "app-2"},
+		{name: "current elsewhere", current: "api", dir: "/src/app", wantErr: "several sessions for /src/app: app, app-2"},
+		{name: "ambiguous", dir: "/src/app", wantErr: "several sessions for /src/app: app, app-2"},
+		{name: "none", dir: "/src/web", wantErr: "no session for /src/web"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			snapshot := domain.Snapshot{Sessions: sessions, CurrentSession: c.current}
+			got, err := sessionForDir(snapshot, c.dir)
+			if c.wantErr != "" {
+				if err == nil || err.Error() != c.wantErr {
+					t.Fatalf("expected error %q, got %v (session %q)", c.wantErr, err, got.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Name != c.want {
+				t.Fatalf("expected %s, got %s", c.want, got.Name)
+			}
+		})
+	}
+}
+
+func TestDownTargets(t *testing.T) {
+	snapshot := domain.Snapshot{
+		CurrentSession: "api",
+		Sessions: []domain.Session{
+			{Name: "app", Path: "/src/app"},
+			{Name: "app-2", Path: "/src/app"},
+			{Name: "api", Path: "/src/api"},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		opts    DownOptions
+		want    []string
+		wantErr string
+	}{
+		{name: "all", opts: DownOptions{All: true, Session: "app"}, want: []string{"app", "app-2", "api"}},
+		{name: "session", opts: DownOptions{Session: "app-2", Cwd: "/src/api"}, want: []string{"app-2"}},
+		{name: "unknown session", opts: DownOptions{Session: "web"}, wantErr: `session "web" not found`},
+		{name: "cwd", opts: DownOptions{Cwd: "/src/api"}, want: []string{"api"}},
+		{name: "ambiguous cwd", opts: DownOptions{Cwd: "/src/app"}, wantErr: "several sessions for /src/app: app, app-2 (use --session or --all)"},
+		{name: "no session for cwd", opts: DownOptions{Cwd: "/tmp"}, wantErr: "no session for /tmp (use --session or --all)"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := downTargets(snapshot, c.opts)
+			if c.wantErr != "" {
+				if err == nil || err.Error() != c.wantErr {
+					t.Fatalf("expected error %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, s := range got {
+				names = append(names, s.Name)
+			}
+			if len(names) != len(c.want) {
+				t.Fatalf("expected %v, got %v", c.want, names)
+			}
+			for i := range names {
+				if names[i] != c.want[i] {
+					t.Fatalf("expected %v, got %v", c.want, names)
+				}
+			}
+		})
+	}
+
+	if _, err := downTargets(domain.Snapshot{}, DownOptions{All: true}); err == nil || err.Error() != "no sessions running" {
+		t.Fatalf("expected no sessions error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/git"
+)
+
+// AnnotateGit fills in repo status and remotes for the sessions in a
+// snapshot. Panes only get status when they sit in a different worktree or
+// branch than their session. Lookups are cached, so this is cheap to call on
+// every dashboard refresh.
+func (a *App) AnnotateGit(snapshot *domain.Snapshot) {
+	for si := range snapshot.Sessions {
+		session := &snapshot.Sessions[si]
+		session.Remote = a.git.Remote(session.Path)
+
+		var sessionStatus git.Status
+		if st, ok := a.git.Status(session.Path); ok {
+			sessionStatus = st
+			session.Git = gitInfo(st)
+		}
+
+		for pi := range session.Panes {
+			pane := &session.Panes[pi]
+			if pane.CurrentPath == "" || pane.CurrentPath == session.Path {
+				continue
+			}
+			st, ok := a.git.Status(pane.CurrentPath)
+			if !ok {
+				continue
+			}
+			if session.Git == nil || st.Root != sessionStatus.Root || st.Branch != sessionStatus.Branch {
+				pane.Git = gitInfo(st)
+			}
+		}
+	}
+}
+
+func gitInfo(st git.Status) *domain.GitInfo {
+	return &domain.GitInfo{
+		Root:    st.Root,
+		Branch:  st.Branch,
+		Head:    st.Head,
+		Dirty:   st.Dirty,
+		Ahead:   st.Ahead,
+		Behind:  st.Behind,
+		Subject: st.Subject,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/minghinmatthewlam/agentpane/internal/config"
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
 )
 
 func (a *App) SnapshotCurrentLayout() (config.Layout, string, error) {
@@ -13,21 +14,71 @@ func (a *App) SnapshotCurrentLayout() (config.Layout, string, error) {
 		return config.Layout{}, "", err
 	}
 
-	panes, err := a.tmux.ListPanes(session)
+	tmpl, err := a.captureSession(session)
 	if err != nil {
 		return config.Layout{}, "", err
 	}
+	return config.Layout{Panes: tmpl.Panes}, session, nil
+}
+
+// captureSession describes a running session as a template: pane types and
+// commands from state (inferred from the running process for panes agentpane
+// did not create), titles, and the window's layout string.
+func (a *App) captureSession(session string) (config.Template, error) {
+	panes, err := a.sessionPanes(session)
+	if err != nil {
+		return config.Template{}, err
+	}
 	if len(panes) == 0 {
-		return config.Layout{}, "", fmt.Errorf("no panes found in session %q", session)
+		return config.Template{}, fmt.Errorf("no panes found in session %q", session)
+	}
+
+	sessionPath, _ := a.tmux.SessionPath(session)
+	if err := a.applyConfigOverrides(sessionPath); err != nil {
+		return config.Template{}, err
+	}
+
+	var recorded []*state.PaneState
+	if ss, ok := a.loadStateOrNew().Sessions[session]; ok {
+		recorded = ss.Panes
+	}
+	specs := a.captureSpecs(panes, recorded)
+
+	layout, err := a.tmux.WindowLayout(session)
+	if err != nil {
+		return config.Template{}, err
+	}
+	return config.Template{Panes: specs, Layout: layout}, nil
+}
+
+// captureSpecs turns running panes into template panes, taking commands from
+// the recorded pane state. An unexpanded config command is kept as written so
+// {{repo}}, {{branch}} and ${VAR} still apply where the template is used;
+// other commands are kept when they differ from the provider's.
+func (a *App) captureSpecs(panes []domain.Pane, recorded []*state.PaneState) []config.PaneSpec {
+	byID := make(map[string]*state.PaneState, len(recorded))
+	for _, p := range recorded {
+		byID[p.TmuxID] = p
 	}
 
 	specs := make([]config.PaneSpec, 0, len(panes))
 	for _, p := range panes {
-		specs = append(specs, config.PaneSpec{
-			Type:  string(domain.InferPaneType("", p.Title)),
-			Title: p.Title,
-		})
+		t := p.Type
+		if t == "" || t == domain.PaneUnknown {
+			t = domain.PaneShell
+		}
+		spec := config.PaneSpec{Type: string(t), Title: p.Title}
+		if ps := byID[p.ID]; ps != nil {
+			switch {
+			case ps.SpecCommand != "":
+				spec.Command = ps.SpecCommand
+			case ps.Command != "":
+				if prov, ok := a.providers.Get(t); !ok || prov.Command != ps.Command {
+					spec.Command = ps.Command
+				}
+			}
+		}
+		specs = append(specs, spec)
 	}
-
-	return config.Layout{Panes: specs}, session, nil
+	return specs
 }
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+func TestCaptureSpecs(t *testing.T) {
+	a := &App{providers: provider.NewRegistry()}
+	panes := []domain.Pane{
+		{ID: "%1", Type: domain.PaneClaude, Title: "claude-1"},
+		{ID: "%2", Type: domain.PaneCodex, Title: "reviewer"},
+		{ID: "%3", Type: domain.PaneShell, Title: "server"},
+		{ID: "%4", Type: domain.PaneUnknown, Title: "scratch"},
+	}
+	recorded := []*state.PaneState{
+		{TmuxID: "%1", Command: "claude"},
+		{TmuxID: "%2", Command: "codex --profile app-feat-x", SpecCommand: "codex --profile {{repo}}-{{branch}}"},
+		{TmuxID: "%3", Command: "npm run dev"},
+	}
+
+	want := []config.PaneSpec{
+		{Type: "claude", Title: "claude-1"},
+		{Type: "codex", Title: "reviewer", Command: "codex --profile {{repo}}-{{branch}}"},
+		{Type: "shell", Title: "server", Command: "npm run dev"},
+		{Type: "shell", Title: "scratch"},
+	}
+	if got := a.captureSpecs(panes, recorded); !reflect.DeepEqual(got, want) {
+		t.Fatalf("captureSpecs:\n got %+v\nwant %+v", got, want)
+	}
+}
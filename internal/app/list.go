@@ -0,0 +1,68 @@
+package app
+
+// ListedSession is a session as printed by `list --json`.
+type ListedSession struct {
+	Name     string       `json:"name"`
+	Path     string       `json:"path"`
+	Profile  string       `json:"profile,omitempty"`
+	Attached bool         `json:"attached"`
+	Pinned   bool         `json:"pinned"`
+	Tags     []string     `json:"tags"`
+	Note     string       `json:"note"`
+	Panes    []ListedPane `json:"panes"`
+}
+
+type ListedPane struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Type     string   `json:"type"`
+	Status   string   `json:"status"`
+	Path     string   `json:"path,omitempty"`
+	Restarts int      `json:"restarts"`
+	Tags     []string `json:"tags"`
+	Note     string   `json:"note"`
+}
+
+// List returns every session with its panes, tags and notes.
+func (a *App) List() ([]ListedSession, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]ListedSession, 0, len(snapshot.Sessions))
+	for _, s := range snapshot.Sessions {
+		ls := ListedSession{
+			Name:     s.Name,
+			Path:     s.Path,
+			Profile:  s.Profile,
+			Attached: s.Attached,
+			Pinned:   s.Pinned,
+			Tags:     nonNil(s.Tags),
+			Note:     s.Note,
+			Panes:    make([]ListedPane, 0, len(s.Panes)),
+		}
+		for _, p := range s.Panes {
+			ls.Panes = append(ls.Panes, ListedPane{
+				ID:       p.ID,
+				Title:    p.Title,
+				Type:     string(p.Type),
+				Status:   string(p.Status),
+				Path:     p.Path,
+				Restarts: p.Restarts,
+				Tags:     nonNil(p.Tags),
+				Note:     p.Note,
+			})
+		}
+		sessions = append(sessions, ls)
+	}
+	return sessions, nil
+}
+
+// nonNil keeps empty tag lists as [] rather than null in JSON.
+func nonNil(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+// MetaTarget is the session or pane whose tags and note are edited. PaneID
+// is empty for the session itself.
+type MetaTarget struct {
+	Session string
+	PaneID  string
+	Title   string
+	Type    domain.PaneType
+}
+
+// String is "<session>/<title>" for panes and the session name otherwise.
+func (t MetaTarget) String() string {
+	if t.PaneID == "" {
+		return t.Session
+	}
+	return t.Session + "/" + t.Title
+}
+
+// TagEdit changes a tag list: Remove is applied first, then Add.
+type TagEdit struct {
+	Add    []string
+	Remove []string
+	// Replace drops every existing tag before Add is applied.
+	Replace bool
+}
+
+// Metadata holds the tags and note of a session or pane.
+type Metadata struct {
+	Tags []string
+	Note string
+}
+
+// ResolveMetaTarget picks what a tag or note command applies to: the pane
+// when one is given, else the session, else the current pane.
+func (a *App) ResolveMetaTarget(session, pane string) (MetaTarget, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return MetaTarget{}, err
+	}
+
+	if pane == "" && session == "" {
+		if snapshot.CurrentPane == "" {
+			return MetaTarget{}, fmt.Errorf("not inside tmux (use --pane or --session)")
+		}
+		pane = snapshot.CurrentPane
+	}
+	if pane == "" {
+		for _, s := range snapshot.Sessions {
+			if s.Name == session {
+				return MetaTarget{Session: s.Name}, nil
+			}
+		}
+		return MetaTarget{}, fmt.Errorf("session %q not found", session)
+	}
+
+	ref, err := a.ResolvePane(pane)
+	if err != nil {
+		return MetaTarget{}, err
+	}
+	if session != "" && ref.Session != session {
+		return MetaTarget{}, fmt.Errorf("pane %s is not in session %s", pane, session)
+	}
+	target := MetaTarget{Session: ref.Session, PaneID: ref.PaneID, Title: ref.Title}
+	for _, s := range snapshot.Sessions {
+		for _, p := range s.Panes {
+			if p.ID == ref.PaneID {
+				target.Type = p.Type
+			}
+		}
+	}
+	return target, nil
+}
+
+// Metadata returns the tags and note recorded for a session or pane.
+func (a *App) Metadata(t MetaTarget) Metadata {
+	ss := a.loadStateOrNew().Sessions[t.Session]
+	if ss == nil {
+		return Metadata{}
+	}
+	if t.PaneID == "" {
+		return Metadata{Tags: ss.Tags, Note: ss.Note}
+	}
+	for _, p := range ss.Panes {
+		if p.TmuxID == t.PaneID {
+			return Metadata{Tags: p.Tags, Note: p.Note}
+		}
+	}
+	return Metadata{}
+}
+
+// EditTags applies an edit to the tags of a session or pane and returns the
+// resulting tags.
+func (a *App) EditTags(t MetaTarget, edit TagEdit) ([]string, error) {
+	var result []string
+	err := a.editMetadata(t, func(m *Metadata) {
+		tags := m.Tags
+		if edit.Replace {
+			tags = nil
+		}
+		tags = state.RemoveTags(tags, edit.Remove...)
+		m.Tags = state.AddTags(tags, edit.Add...)
+		result = m.Tags
+	})
+	return result, err
+}
+
+// SetNote replaces the note of a session or pane; an empty note clears it.
+func (a *App) SetNote(t MetaTarget, note string) error {
+	return a.editMetadata(t, func(m *Metadata) {
+		m.Note = strings.TrimSpace(note)
+	})
+}
+
+// editMetadata loads the metadata of a target, lets edit change it and saves
+// it back, adding the session or pane to state if it isn't tracked yet.
+func (a *App) editMetadata(t MetaTarget, edit func(*Metadata)) error {
+	return a.updateState(func(st *state.Store) error {
+		ss, ok := st.Sessions[t.Session]
+		if !ok {
+			path, _ := a.tmux.SessionPath(t.Session)
+			ss = &state.SessionState{
+				Path:      path,
+				CreatedAt: time.Now(),
+				Panes:     []*state.PaneState{},
+			}
+			st.Sessions[t.Session] = ss
+		}
+
+		if t.PaneID == "" {
+			m := Metadata{Tags: ss.Tags, Note: ss.Note}
+			edit(&m)
+			ss.Tags, ss.Note = m.Tags, m.Note
+			return a.attachServerID(st)
+		}
+
+		ps := findPaneState(ss, t.PaneID)
+		if ps == nil {
+			paneType := t.Type
+			if paneType == "" {
+				paneType = domain.PaneUnknown
+			}
+			ps = &state.PaneState{
+				TmuxID:    t.PaneID,
+				Type:      string(paneType),
+				Title:     t.Title,
+				CreatedAt: time.Now(),
+			}
+			ss.Panes = append(ss.Panes, ps)
+		}
+		m := Metadata{Tags: ps.Tags, Note: ps.Note}
+		edit(&m)
+		ps.Tags, ps.Note = m.Tags, m.Note
+		return a.attachServerID(st)
+	})
+}
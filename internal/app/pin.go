@@ -0,0 +1,25 @@
+package app
+
+import "github.com/minghinmatthewlam/agentpane/internal/state"
+
+// TogglePin pins or unpins a session in the dashboard and reports whether it
+// is pinned afterwards.
+func (a *App) TogglePin(name string) (bool, error) {
+	pinned := true
+	err := a.updateState(func(st *state.Store) error {
+		var kept []string
+		for _, p := range st.Pinned {
+			if p == name {
+				pinned = false
+				continue
+			}
+			kept = append(kept, p)
+		}
+		if pinned {
+			kept = append(kept, name)
+		}
+		st.Pinned = kept
+		return a.attachServerID(st)
+	})
+	return pinned, err
+}
@@ -1,16 +1,22 @@
 package app
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/minghinmatthewlam/agentpane/internal/config"
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
 	"github.com/minghinmatthewlam/agentpane/internal/provider"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
 )
 
 func (a *App) applyProviderOverrides(cfg *config.Config) {
 	a.providers = provider.NewRegistry()
+	a.providerConfigs = nil
 	if cfg == nil {
 		return
 	}
+	a.providerConfigs = cfg.Providers
 	for k, v := range cfg.Providers {
 		if v.Command == "" {
 			continue
@@ -25,3 +31,73 @@ func (a *App) applyProviderOverrides(cfg *config.Config) {
 		}
 	}
 }
+
+// paneExitOption is a tmux pane option that wrapped launch commands set to
+// the agent's exit status, so the supervisor can tell failures from clean exits.
+const paneExitOption = "@agentpane_exit"
+
+// restartPolicyFor resolves the restart policy for a pane of type t. It
+// returns nil when the pane should never be restarted.
+func (a *App) restartPolicyFor(t domain.PaneType, spec config.RestartPolicy) *state.RestartPolicy {
+	if t == domain.PaneShell {
+		return nil
+	}
+	resolved := spec.Resolve(a.providerConfigs[string(t)].RestartPolicy)
+	if resolved.Restart == config.RestartNever {
+		return nil
+	}
+	return &state.RestartPolicy{
+		Restart:     resolved.Restart,
+		MaxRestarts: resolved.MaxRestarts,
+		Backoff:     resolved.RestartBackoff,
+	}
+}
+
+// withRestartPolicy returns a copy of prov whose command records its exit
+// status when the policy needs to distinguish failures.
+func withRestartPolicy(prov *provider.Provider, policy *state.RestartPolicy) *provider.Provider {
+	if policy == nil || policy.Restart != config.RestartOnFailure {
+		launch := *prov
+		return &launch
+	}
+	return withExitStatus(prov)
+}
+
+// withExitStatus returns a copy of prov whose command records its exit
+// status in paneExitOption.
+func withExitStatus(prov *provider.Provider) *provider.Provider {
+	launch := *prov
+	if launch.Command == "" {
+		return &launch
+	}
+	launch.Command = recordExitStatus(launch.Command)
+	return &launch
+}
+
+// recordExitStatus appends a shell command that stores command's exit status
+// in paneExitOption.
+func recordExitStatus(command string) string {
+	status := "$?"
+	if filepath.Base(provider.DefaultShell()) == "fish" {
+		status = "$status"
+	}
+	return command + `; tmux set-option -p -t "$TMUX_PANE" ` + paneExitOption + " " + status
+}
+
+// restartBackoff returns how long to wait after the last restart before
+// restarting again. The base backoff doubles with every restart, up to 5m.
+func restartBackoff(policy *state.RestartPolicy, restarts int) time.Duration {
+	base, err := time.ParseDuration(policy.Backoff)
+	if err != nil || base <= 0 {
+		base = config.DefaultRestartBackoff
+	}
+	const maxBackoff = 5 * time.Minute
+	d := base
+	for i := 1; i < restarts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
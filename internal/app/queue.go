@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+const dispatchInterval = time.Second
+
+// QueuedPrompt is a prompt to add to the queue, with the file it came from.
+type QueuedPrompt struct {
+	Prompt string
+	Source string
+}
+
+// QueueSession resolves the session a queue command applies to: the
+// explicit one, else the current session or the one started in the
+// working directory.
+func (a *App) QueueSession(explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return "", err
+	}
+	if snapshot.CurrentSession != "" {
+		return snapshot.CurrentSession, nil
+	}
+	cwd, _ := os.Getwd()
+	s, err := sessionForDir(snapshot, cwd)
+	if err != nil {
+		return "", fmt.Errorf("%w (use --session)", err)
+	}
+	return s.Name, nil
+}
+
+// QueueAdd appends prompts to a session's queue and returns the new tasks.
+func (a *App) QueueAdd(session string, prompts []QueuedPrompt) ([]state.Task, error) {
+	var added []state.Task
+	err := a.updateState(func(st *state.Store) error {
+		now := time.Now()
+		added = make([]state.Task, 0, len(prompts))
+		for _, p := range prompts {
+			added = append(added, *st.AddTask(session, p.Prompt, p.Source, now))
+		}
+		return a.attachServerID(st)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// QueueTasks lists the tasks of a session, or of every session when it is
+// empty, in queue order.
+func (a *App) QueueTasks(session string) []state.Task {
+	var out []state.Task
+	for _, t := range a.loadStateOrNew().Tasks {
+		if session == "" || t.Session == session {
+			out = append(out, *t)
+		}
+	}
+	return out
+}
+
+// ClearQueue removes finished tasks and returns how many were removed.
+func (a *App) ClearQueue(session string) (int, error) {
+	removed := 0
+	err := a.updateState(func(st *state.Store) error {
+		removed = st.ClearFinished(session)
+		return a.attachServerID(st)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+type DispatchOptions struct {
+	Session string
+	// IdleFor is how long an agent's output must be unchanged for it to
+	// count as free, and for its task to count as done.
+	IdleFor time.Duration
+	// Follow keeps dispatching after the queue empties, for tasks added later.
+	Follow bool
+}
+
+type DispatchEvent struct {
+	Task state.Task
+	// Action is "started", "done" or "failed".
+	Action string
+	Err    error
+}
+
+// Dispatch hands queued tasks of a session to its agent panes as they
+// become idle, and marks a task done once its pane goes idle again or
+// failed if the agent exits. It returns when the queue is empty and no task
+// is running, unless opts.Follow is set, or when ctx is done.
+func (a *App) Dispatch(ctx context.Context, opts DispatchOptions, report func(DispatchEvent)) error {
+	if opts.IdleFor <= 0 {
+		opts.IdleFor = DefaultWaitIdle
+	}
+	tracker := provider.NewActivityTracker(opts.IdleFor)
+
+	for {
+		pending, err := a.dispatchOnce(opts, tracker, report)
+		if err != nil {
+			return err
+		}
+		if pending == 0 && !opts.Follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(dispatchInterval):
+		}
+	}
+}
+
+// dispatchOnce updates running tasks and starts queued ones on free panes.
+// It returns how many tasks are still queued or running.
+func (a *App) dispatchOnce(opts DispatchOptions, tracker *provider.ActivityTracker, report func(DispatchEvent)) (int, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return 0, err
+	}
+	var panes []domain.Pane
+	found := false
+	for _, s := range snapshot.Sessions {
+		if s.Name == opts.Session {
+			found = true
+			panes = s.Panes
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("session %q not found", opts.Session)
+	}
+
+	now := time.Now()
+	live := map[string]domain.Pane{}
+	idle := map[string]bool{}
+	for _, p := range panes {
+		live[p.ID] = p
+		if content, err := a.tmux.CapturePaneContent(p.ID); err == nil {
+			idle[p.ID] = tracker.Observe(p.ID, content, now)
+		}
+	}
+
+	// Tasks are picked and marked running in one locked update, so two
+	// dispatchers can't hand the same task to different panes.
+	pending := 0
+	err = a.updateState(func(st *state.Store) error {
+		changed := false
+		busy := map[string]bool{}
+		for _, t := range st.Tasks {
+			if t.Session != opts.Session || t.Status != state.TaskRunning {
+				continue
+			}
+			p, ok := live[t.PaneID]
+			switch {
+			case !ok || p.Status == domain.StatusExited:
+				t.Finish(state.TaskFailed, now)
+				report(DispatchEvent{Task: *t, Action: "failed", Err: fmt.Errorf("agent exited")})
+				changed = true
+			// The pane must also have been quiet for the whole period since
+			// the prompt, in case the paste has not shown up yet.
+			case idle[t.PaneID] && t.StartedAt != nil && now.Sub(*t.StartedAt) >= opts.IdleFor:
+				t.Finish(state.TaskDone, now)
+				report(DispatchEvent{Task: *t, Action: "done"})
+				changed = true
+			default:
+				busy[t.PaneID] = true
+			}
+		}
+
+		for _, p := range panes {
+			if p.Type == domain.PaneShell || p.Status != domain.StatusActive || busy[p.ID] || !idle[p.ID] {
+				continue
+			}
+			t := st.NextTask(opts.Session)
+			if t == nil {
+				break
+			}
+			if err := a.deliverPrompt(p.ID, t.Prompt); err != nil {
+				// Leave the task queued for the next free pane.
+				a.logger.Printf("failed to send task %d to %s: %v", t.ID, p.ID, err)
+				continue
+			}
+			t.Start(p.ID, p.Title, time.Now())
+			busy[p.ID] = true
+			report(DispatchEvent{Task: *t, Action: "started"})
+			changed = true
+		}
+
+		for _, t := range st.Tasks {
+			if t.Session == opts.Session && (t.Status == state.TaskQueued || t.Status == state.TaskRunning) {
+				pending++
+			}
+		}
+		if !changed {
+			return errUnchanged
+		}
+		return a.attachServerID(st)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pending, nil
+}
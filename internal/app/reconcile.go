@@ -17,13 +17,6 @@ func (a *App) Reconcile() error {
 		return err
 	}
 
-	current := a.loadStateOrNew()
-	if current.ServerID != "" && current.ServerID != serverID {
-		a.logger.Printf("state server id mismatch (state=%s, tmux=%s); resetting state", current.ServerID, serverID)
-		current = state.NewStore()
-	}
-	current.ServerID = serverID
-
 	tmuxSessions, err := a.tmux.ListSessions()
 	if err != nil {
 		return err
@@ -33,18 +26,31 @@ func (a *App) Reconcile() error {
 		return err
 	}
 
-	output := state.Reconcile(state.ReconcileInput{
-		CurrentState: current,
-		TmuxSessions: sessions,
+	var output state.ReconcileOutput
+	err = a.updateState(func(current *state.Store) error {
+		if current.ServerID != "" && current.ServerID != serverID {
+			a.logger.Printf("state server id mismatch (state=%s, tmux=%s); resetting state", current.ServerID, serverID)
+			*current = *state.NewStore()
+		}
+		current.ServerID = serverID
+
+		output = state.Reconcile(state.ReconcileInput{
+			CurrentState: current,
+			TmuxSessions: sessions,
+		})
+		*current = *output.UpdatedState
+		return nil
 	})
+	if err != nil {
+		return err
+	}
 
 	for _, update := range output.TitleUpdates {
 		if err := a.tmux.SetPaneTitle(update.PaneID, update.Title); err != nil {
 			a.logger.Printf("failed to set pane title %s: %v", update.PaneID, err)
 		}
 	}
-
-	return a.state.Save(output.UpdatedState)
+	return nil
 }
 
 func (a *App) ensureServerID() (string, error) {
@@ -81,11 +87,12 @@ func (a *App) convertSessions(raw []tmux.RawSession) ([]domain.Session, error) {
 			return nil, err
 		}
 		converted := domain.Session{
-			Name:      s.Name,
-			Path:      s.Path,
-			CreatedAt: parseCreatedAt(s.Created),
-			Attached:  s.Attached == "1",
-			Panes:     convertPanes(panes),
+			Name:         s.Name,
+			Path:         s.Path,
+			CreatedAt:    parseCreatedAt(s.Created),
+			Attached:     s.Attached == "1",
+			LastActivity: parseCreatedAt(s.Activity),
+			Panes:        convertPanes(panes),
 		}
 		out = append(out, converted)
 	}
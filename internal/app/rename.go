@@ -105,41 +105,31 @@ func promptForTitle(current string) (string, error) {
 }
 
 func (a *App) updateStateForRename(session, paneID, title string) error {
-	st := a.loadStateOrNew()
-
-	ss, ok := st.Sessions[session]
-	if !ok {
-		path, _ := a.tmux.SessionPath(session)
-		ss = &state.SessionState{
-			Path:      path,
-			CreatedAt: time.Now(),
-			Panes:     []*state.PaneState{},
-		}
-		st.Sessions[session] = ss
-	}
-
-	now := time.Now()
-	for _, p := range ss.Panes {
-		if p.TmuxID == paneID {
-			p.Title = title
-			p.RenamedAt = &now
-			if err := a.attachServerID(st); err != nil {
-				return err
+	return a.updateState(func(st *state.Store) error {
+		ss, ok := st.Sessions[session]
+		if !ok {
+			path, _ := a.tmux.SessionPath(session)
+			ss = &state.SessionState{
+				Path:      path,
+				CreatedAt: time.Now(),
+				Panes:     []*state.PaneState{},
 			}
-			return a.state.Save(st)
+			st.Sessions[session] = ss
 		}
-	}
 
-	ss.Panes = append(ss.Panes, &state.PaneState{
-		TmuxID:    paneID,
-		Type:      "unknown",
-		Title:     title,
-		CreatedAt: now,
-		RenamedAt: &now,
+		now := time.Now()
+		if ps := findPaneState(ss, paneID); ps != nil {
+			ps.Title = title
+			ps.RenamedAt = &now
+		} else {
+			ss.Panes = append(ss.Panes, &state.PaneState{
+				TmuxID:    paneID,
+				Type:      "unknown",
+				Title:     title,
+				CreatedAt: now,
+				RenamedAt: &now,
+			})
+		}
+		return a.attachServerID(st)
 	})
-
-	if err := a.attachServerID(st); err != nil {
-		return err
-	}
-	return a.state.Save(st)
 }
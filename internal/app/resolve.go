@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+// PaneRef identifies a single pane within a session.
+type PaneRef struct {
+	Session string
+	PaneID  string
+	Title   string
+}
+
+// ResolvePane finds a pane from user input. Accepted forms are a tmux pane ID
+// ("%3"), "<session>/<title>", or a bare title, which is looked up in the
+// current session first and then across all sessions.
+func (a *App) ResolvePane(ref string) (PaneRef, error) {
+	if strings.TrimSpace(ref) == "" {
+		return PaneRef{}, fmt.Errorf("pane is required")
+	}
+
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return PaneRef{}, err
+	}
+	return resolvePane(snapshot, ref)
+}
+
+func resolvePane(snapshot domain.Snapshot, ref string) (PaneRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return PaneRef{}, fmt.Errorf("pane is required")
+	}
+
+	if strings.HasPrefix(ref, "%") {
+		for _, s := range snapshot.Sessions {
+			for _, p := range s.Panes {
+				if p.ID == ref {
+					return PaneRef{Session: s.Name, PaneID: p.ID, Title: p.Title}, nil
+				}
+			}
+		}
+		return PaneRef{}, fmt.Errorf("pane %s not found", ref)
+	}
+
+	if sessionName, title, ok := strings.Cut(ref, "/"); ok {
+		for _, s := range snapshot.Sessions {
+			if s.Name != sessionName {
+				continue
+			}
+			if p := findPaneByTitle(s, title); p != nil {
+				return PaneRef{Session: s.Name, PaneID: p.ID, Title: p.Title}, nil
+			}
+			return PaneRef{}, fmt.Errorf("pane %q not found in session %s", title, sessionName)
+		}
+		return PaneRef{}, fmt.Errorf("session %q not found", sessionName)
+	}
+
+	if snapshot.CurrentSession != "" {
+		for _, s := range snapshot.Sessions {
+			if s.Name != snapshot.CurrentSession {
+				continue
+			}
+			if p := findPaneByTitle(s, ref); p != nil {
+				return PaneRef{Session: s.Name, PaneID: p.ID, Title: p.Title}, nil
+			}
+		}
+	}
+
+	var matches []PaneRef
+	for _, s := range snapshot.Sessions {
+		if p := findPaneByTitle(s, ref); p != nil {
+			matches = append(matches, PaneRef{Session: s.Name, PaneID: p.ID, Title: p.Title})
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return PaneRef{}, fmt.Errorf("pane %q not found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, m := range matches {
+			names = append(names, m.Session+"/"+m.Title)
+		}
+		return PaneRef{}, fmt.Errorf("pane %q is ambiguous (%s)", ref, strings.Join(names, ", "))
+	}
+}
+
+func findPaneByTitle(s domain.Session, title string) *domain.Pane {
+	for i := range s.Panes {
+		if s.Panes[i].Title == title {
+			return &s.Panes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+func TestResolvePane(t *testing.T) {
+	snapshot := domain.Snapshot{
+		CurrentSession: "app",
+		Sessions: []domain.Session{
+			{Name: "app", Panes: []domain.Pane{
+				{ID: "%1", Title: "claude-1"},
+				{ID: "%2", Title: "shell"},
+			}},
+			{Name: "api", Panes: []domain.Pane{
+				{ID: "%3", Title: "claude-1"},
+				{ID: "%4", Title: "codex-1"},
+				{ID: "%5", Title: "server"},
+			}},
+			{Name: "web", Panes: []domain.Pane{
+				{ID: "%6", Title: "server"},
+			}},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{name: "id", ref: "%4", want: "%4"},
+		{name: "id padded", ref: " %5 ", want: "%5"},
+		{name: "unknown id", ref: "%9", wantErr: "pane %9 not found"},
+		{name: "session/title", ref: "api/claude-1", want: "%3"},
+		{name: "title missing in session", ref: "web/claude-1", wantErr: `pane "claude-1" not found in session web`},
+		{name: "unknown session", ref: "nope/claude-1", wantErr: `session "nope" not found`},
+		{name: "current session first", ref: "claude-1", want: "%1"},
+		{name: "unique title elsewhere", ref: "codex-1", want: "%4"},
+		{name: "ambiguous title", ref: "server", wantErr: `pane "server" is ambiguous (api/server, web/server)`},
+		{name: "unknown title", ref: "vim", wantErr: `pane "vim" not found`},
+		{name: "empty", ref: "  ", wantErr: "pane is required"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolvePane(snapshot, c.ref)
+			if c.wantErr != "" {
+				if err == nil || err.Error() != c.wantErr {
+					t.Fatalf("expected error %q, got %v (%#v)", c.wantErr, err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.PaneID != c.want {
+				t.Fatalf("expected %s, got %#v", c.want, got)
+			}
+		})
+	}
+}
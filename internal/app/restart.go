@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+type RestartOptions struct {
+	Session string
+	PaneID  string
+	// Reason is recorded in the pane's restart log; defaults to "manual".
+	Reason string
+	// Supervised marks the restart as the supervisor's, which counts
+	// against the pane's max_restarts.
+	Supervised bool
+}
+
+type RestartResult struct {
+	Title    string
+	Restarts int
+}
+
+// RestartPane relaunches the pane's recorded provider command in the same
+// tmux pane, keeping its ID, title and state entry.
+func (a *App) RestartPane(opts RestartOptions) (RestartResult, error) {
+	sessionPath, err := a.tmux.SessionPath(opts.Session)
+	if err != nil {
+		return RestartResult{}, err
+	}
+	if err := a.applyConfigOverrides(sessionPath); err != nil {
+		return RestartResult{}, err
+	}
+
+	st := a.loadStateOrNew()
+	ss, ok := st.Sessions[opts.Session]
+	if !ok {
+		return RestartResult{}, fmt.Errorf("session %s has no recorded state", opts.Session)
+	}
+	var ps *state.PaneState
+	for _, p := range ss.Panes {
+		if p.TmuxID == opts.PaneID {
+			ps = p
+			break
+		}
+	}
+	if ps == nil {
+		return RestartResult{}, fmt.Errorf("pane %s has no recorded state", opts.PaneID)
+	}
+
+	prov, ok := a.providers.Get(domain.PaneType(ps.Type))
+	if !ok {
+		return RestartResult{}, fmt.Errorf("cannot restart pane of type %q", ps.Type)
+	}
+	recorded := *prov
+	if ps.Command != "" {
+		recorded.Command = ps.Command
+	}
+
+	dir := ps.Path
+	if dir == "" {
+		dir = sessionPath
+	}
+	if err := a.tmux.RespawnPane(opts.PaneID, dir); err != nil {
+		return RestartResult{}, err
+	}
+	if err := a.tmux.SetPaneTitle(opts.PaneID, ps.Title); err != nil {
+		return RestartResult{}, err
+	}
+	_ = a.tmux.UnsetPaneOption(opts.PaneID, paneExitOption)
+	if err := a.launchProvider(opts.PaneID, withRestartPolicy(&recorded, ps.RestartPolicy)); err != nil {
+		return RestartResult{}, err
+	}
+
+	reason := opts.Reason
+	if reason == "" {
+		reason = "manual"
+	}
+	// Record the restart on freshly loaded state, since other commands may
+	// have saved changes while the pane was being relaunched.
+	result := RestartResult{Title: ps.Title, Restarts: ps.Restarts + 1}
+	err = a.updateState(func(st *state.Store) error {
+		ss, ok := st.Sessions[opts.Session]
+		if !ok {
+			return nil
+		}
+		if fresh := findPaneState(ss, opts.PaneID); fresh != nil {
+			fresh.RecordRestart(time.Now(), reason, opts.Supervised)
+			fresh.Command = recorded.Command
+			result = RestartResult{Title: fresh.Title, Restarts: fresh.Restarts}
+		}
+		return a.attachServerID(st)
+	})
+	if err != nil {
+		return RestartResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,321 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/git"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+// Defaults for RunOptions.
+const (
+	DefaultRunTimeout = 30 * time.Minute
+	DefaultRunIdle    = 30 * time.Second
+)
+
+// runReadyQuiet is how long an agent's screen must be still after launch
+// before the prompt is delivered.
+const runReadyQuiet = 2 * time.Second
+
+type RunOptions struct {
+	Type   domain.PaneType
+	Prompt string
+	// Session is created in Cwd if it doesn't exist. When empty, the repo's
+	// session is used, as with `up`.
+	Session string
+	Title   string
+	Cwd     string
+	// OutputDir receives the results. Defaults to a new directory under
+	// ~/.local/share/agentpane/runs.
+	OutputDir string
+	// Timeout bounds the whole run, including agent startup.
+	Timeout time.Duration
+	// Idle is how long the pane must be unchanged for the agent to count as
+	// done and waiting for input.
+	Idle time.Duration
+}
+
+// RunOutcome is how a run ended.
+type RunOutcome string
+
+const (
+	RunIdle    RunOutcome = "idle"
+	RunExited  RunOutcome = "exited"
+	RunTimeout RunOutcome = "timeout"
+)
+
+type RunResult struct {
+	Session string     `json:"session"`
+	PaneID  string     `json:"pane_id"`
+	Title   string     `json:"title"`
+	Type    string     `json:"type"`
+	Outcome RunOutcome `json:"outcome"`
+	// ExitStatus is the agent's exit status when Outcome is RunExited, or
+	// -1 when it is unknown.
+	ExitStatus int       `json:"exit_status"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished"`
+	Dir        string    `json:"dir"`
+	OutputDir  string    `json:"output_dir"`
+	// Files lists the files changed in Dir since the run started.
+	Files []string `json:"files"`
+}
+
+// Succeeded reports whether the agent finished its task: it went idle, or
+// exited with status 0.
+func (r RunResult) Succeeded() bool {
+	switch r.Outcome {
+	case RunIdle:
+		return true
+	case RunExited:
+		return r.ExitStatus == 0
+	}
+	return false
+}
+
+// Run starts an agent in a new pane, gives it a prompt and waits until it is
+// idle, exits or times out. The transcript, the git diff of the pane's
+// directory since the start and a result.json are saved to the output
+// directory. The pane is left open for inspection.
+func (a *App) Run(opts RunOptions) (RunResult, error) {
+	if strings.TrimSpace(opts.Prompt) == "" {
+		return RunResult{}, fmt.Errorf("prompt must not be empty")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultRunTimeout
+	}
+	if opts.Idle <= 0 {
+		opts.Idle = DefaultRunIdle
+	}
+	started := time.Now()
+	deadline := started.Add(opts.Timeout)
+
+	paneID, session, title, dir, err := a.spawnRunPane(opts)
+	if err != nil {
+		return RunResult{}, err
+	}
+	result := RunResult{
+		Session:    session,
+		PaneID:     paneID,
+		Title:      title,
+		Type:       string(opts.Type),
+		ExitStatus: -1,
+		Started:    started,
+		Dir:        dir,
+	}
+	// Snapshot the work tree, uncommitted changes included, so the saved
+	// diff only shows what the run changed. It fails outside a git repo.
+	base, _ := git.Snapshot(dir)
+
+	// Shell panes run the prompt as a command. Agents get it once they have
+	// started up and their screen has settled.
+	ready := RunIdle
+	if opts.Type == domain.PaneShell {
+		err = a.tmux.SendKeysLiteral(paneID, recordExitStatus(opts.Prompt))
+		if err == nil {
+			err = a.tmux.SendEnter(paneID)
+		}
+	} else if ready, result.ExitStatus = a.waitForPane(paneID, runReadyQuiet, deadline); ready == RunIdle {
+		err = a.deliverPrompt(paneID, opts.Prompt)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if ready == RunIdle {
+		idle := opts.Idle
+		if opts.Type == domain.PaneShell {
+			// A quiet shell may still be running the command; wait for it.
+			idle = 0
+		}
+		result.Outcome, result.ExitStatus = a.waitForPane(paneID, idle, deadline)
+	} else {
+		result.Outcome = ready
+	}
+	result.Finished = time.Now()
+
+	if err := a.saveRun(&result, opts.OutputDir, base); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// spawnRunPane creates the pane a run happens in, creating the session if
+// needed, and launches the agent with its exit status recorded.
+func (a *App) spawnRunPane(opts RunOptions) (paneID, session, title, dir string, err error) {
+	loaded, err := a.loadConfig(opts.Cwd)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	a.applyProviderOverrides(loaded.Merged)
+
+	prov, actualType, ok := a.providers.GetWithFallback(opts.Type)
+	if !ok {
+		return "", "", "", "", fmt.Errorf("unknown pane type: %s", opts.Type)
+	}
+	if actualType != opts.Type {
+		return "", "", "", "", fmt.Errorf("%s not found in PATH", opts.Type)
+	}
+
+	baseOverride := ""
+	if loaded.Repo != nil {
+		baseOverride = strings.TrimSpace(loaded.Repo.Session)
+	}
+	session, err = a.resolveSessionName(opts.Cwd, opts.Session, baseOverride, true)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	exists, err := a.tmux.HasSession(session)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	if exists {
+		if dir, err = a.tmux.SessionPath(session); err != nil {
+			return "", "", "", "", err
+		}
+		if paneID, err = a.tmux.SplitPane(session, dir); err != nil {
+			return "", "", "", "", err
+		}
+		// Retile so repeated runs in one session keep room to split.
+		if panes, err := a.tmux.ListPanes(session); err == nil {
+			a.selectLayout(session, "", len(panes))
+		}
+	} else {
+		dir = opts.Cwd
+		if err := a.tmux.NewSession(session, dir, loaded.Merged.Env); err != nil {
+			return "", "", "", "", err
+		}
+		if err := a.prepareTemplateSession(session, nil); err != nil {
+			return "", "", "", "", err
+		}
+		panes, err := a.tmux.ListPanes(session)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		paneID = panes[0].ID
+	}
+
+	title = strings.TrimSpace(opts.Title)
+	if title == "" {
+		if title, err = a.nextAutoTitle(session, actualType, prov); err != nil {
+			return "", "", "", "", err
+		}
+	}
+	if err := a.tmux.SetPaneTitle(paneID, title); err != nil {
+		return "", "", "", "", err
+	}
+	if err := a.launchProvider(paneID, withExitStatus(prov)); err != nil {
+		return "", "", "", "", err
+	}
+	if err := a.updateStateForNewPane(session, paneID, dir, paneConfigResult{Type: actualType, Title: title, Command: prov.Command}); err != nil {
+		return "", "", "", "", err
+	}
+	return paneID, session, title, dir, nil
+}
+
+// deliverPrompt pastes the prompt into the agent and submits it.
+func (a *App) deliverPrompt(paneID, prompt string) error {
+	if err := a.tmux.PasteText(paneID, strings.TrimRight(prompt, "\n")); err != nil {
+		return err
+	}
+	// Give the agent a moment to take in the paste before submitting.
+	time.Sleep(300 * time.Millisecond)
+	return a.tmux.SendEnter(paneID)
+}
+
+// waitForPane polls a pane until its content has been still for quiet, it
+// records an exit status, or the deadline passes. A zero quiet waits for the
+// exit status only. The exit status is -1 unless the outcome is RunExited
+// and the status was recorded.
+func (a *App) waitForPane(paneID string, quiet time.Duration, deadline time.Time) (RunOutcome, int) {
+	tracker := provider.NewActivityTracker(quiet)
+	for {
+		status, err := a.tmux.PaneOption(paneID, paneExitOption)
+		if err != nil {
+			// The pane is gone, so whatever ran in it has exited.
+			return RunExited, -1
+		}
+		if status != "" {
+			n, err := strconv.Atoi(status)
+			if err != nil {
+				n = -1
+			}
+			return RunExited, n
+		}
+
+		now := time.Now()
+		if quiet > 0 {
+			if content, err := a.tmux.CapturePaneContent(paneID); err == nil && tracker.Observe(paneID, content, now) {
+				return RunIdle, -1
+			}
+		}
+		if now.After(deadline) {
+			return RunTimeout, -1
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// saveRun writes the transcript, diff and result.json of a finished run.
+func (a *App) saveRun(result *RunResult, outputDir, base string) error {
+	if outputDir == "" {
+		base, err := state.RunsDir()
+		if err != nil {
+			return err
+		}
+		name := result.Session + "-" + result.Title + "-" + result.Started.Format("20060102-150405")
+		outputDir = filepath.Join(base, unsafeFileChars.ReplaceAllString(name, "_"))
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	result.OutputDir = outputDir
+
+	history, err := a.tmux.CapturePaneHistory(result.PaneID)
+	if err == nil {
+		transcript := strings.TrimRight(ansi.Strip(history), " \n") + "\n"
+		if err := os.WriteFile(filepath.Join(outputDir, "transcript.txt"), []byte(transcript), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if base != "" {
+		if diff, err := git.DiffSince(result.Dir, base); err == nil {
+			if err := os.WriteFile(filepath.Join(outputDir, "changes.diff"), []byte(diff), 0o644); err != nil {
+				return err
+			}
+			result.Files = changedFiles(diff)
+		}
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, "result.json"), append(data, '\n'), 0o644)
+}
+
+// changedFiles lists the files in a diff produced by git.DiffSince.
+func changedFiles(diff string) []string {
+	files := []string{}
+	for _, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "diff --git a/") {
+			continue
+		}
+		if _, b, ok := strings.Cut(line, " b/"); ok {
+			files = append(files, b)
+		}
+	}
+	return files
+}
@@ -11,6 +11,8 @@ type SearchResult struct {
 	PaneID  string
 	Title   string
 	Type    domain.PaneType
+	Tags    []string
+	Note    string
 }
 
 func (a *App) Search(query string) ([]SearchResult, error) {
@@ -26,18 +28,22 @@ func (a *App) Search(query string) ([]SearchResult, error) {
 
 	var results []SearchResult
 	for _, session := range snapshot.Sessions {
-		if strings.Contains(strings.ToLower(session.Name), query) {
+		if matchesQuery(query, session.Name, session.Tags, session.Note) {
 			results = append(results, SearchResult{
 				Session: session.Name,
+				Tags:    session.Tags,
+				Note:    session.Note,
 			})
 		}
 		for _, pane := range session.Panes {
-			if strings.Contains(strings.ToLower(pane.Title), query) {
+			if matchesQuery(query, pane.Title, pane.Tags, pane.Note) {
 				results = append(results, SearchResult{
 					Session: session.Name,
 					PaneID:  pane.ID,
 					Title:   pane.Title,
 					Type:    pane.Type,
+					Tags:    pane.Tags,
+					Note:    pane.Note,
 				})
 			}
 		}
@@ -45,3 +51,22 @@ func (a *App) Search(query string) ([]SearchResult, error) {
 
 	return results, nil
 }
+
+// matchesQuery reports whether a lowercase query appears in a name or note,
+// or names one of the tags ("#tag" or "tag").
+func matchesQuery(query, name string, tags []string, note string) bool {
+	if strings.Contains(strings.ToLower(name), query) || strings.Contains(strings.ToLower(note), query) {
+		return true
+	}
+	// A bare "#" names no tag.
+	term := strings.TrimPrefix(query, "#")
+	if term == "" {
+		return false
+	}
+	for _, tag := range tags {
+		if strings.Contains(strings.ToLower(tag), term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestMatchesQuery(t *testing.T) {
+	tags := []string{"infra", "urgent"}
+	cases := []struct {
+		query string
+		name  string
+		tags  []string
+		note  string
+		want  bool
+	}{
+		{query: "api", name: "api-server", want: true},
+		{query: "schema", name: "app", note: "Fixing the Schema", want: true},
+		{query: "#infra", name: "app", tags: tags, want: true},
+		{query: "urg", name: "app", tags: tags, want: true},
+		{query: "#docs", name: "app", tags: tags, want: false},
+		{query: "#", name: "app", tags: tags, want: false},
+		{query: "#", name: "app", note: "see #42", want: true},
+	}
+	for _, c := range cases {
+		if got := matchesQuery(c.query, c.name, c.tags, c.note); got != c.want {
+			t.Errorf("matchesQuery(%q, %q, %v, %q) = %v, want %v", c.query, c.name, c.tags, c.note, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+// SendInput types text into a pane and presses Enter. Non-empty text is
+// recorded in the pane's input history.
+func (a *App) SendInput(session, paneID, text string) error {
+	if text != "" {
+		if err := a.tmux.SendKeysLiteral(paneID, text); err != nil {
+			return err
+		}
+	}
+	if err := a.tmux.SendEnter(paneID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	return a.updateState(func(st *state.Store) error {
+		ss, ok := st.Sessions[session]
+		if !ok {
+			return nil
+		}
+		ps := findPaneState(ss, paneID)
+		if ps == nil {
+			return nil
+		}
+		ps.RecordInput(text)
+		return a.attachServerID(st)
+	})
+}
+
+// SendKeys sends tmux key names (e.g. "Escape", "C-c") to a pane.
+func (a *App) SendKeys(paneID string, keys ...string) error {
+	return a.tmux.SendKeys(paneID, keys...)
+}
+
+// InputHistory returns the messages previously sent to a pane, oldest first.
+func (a *App) InputHistory(session, paneID string) []string {
+	st := a.loadStateOrNew()
+	ss, ok := st.Sessions[session]
+	if !ok {
+		return nil
+	}
+	if ps := findPaneState(ss, paneID); ps != nil {
+		return append([]string(nil), ps.InputHistory...)
+	}
+	return nil
+}
@@ -21,14 +21,22 @@ func (a *App) Snapshot() (domain.Snapshot, error) {
 	}
 
 	st := a.loadStateOrNew()
+	pinned := make(map[string]bool, len(st.Pinned))
+	for _, name := range st.Pinned {
+		pinned[name] = true
+	}
 
 	detector := provider.NewStatusDetector(a.providers)
 
 	for si := range sessions {
 		session := &sessions[si]
+		session.Pinned = pinned[session.Name]
 		stateSession := st.Sessions[session.Name]
 		statePaneMap := map[string]*state.PaneState{}
 		if stateSession != nil {
+			session.Profile = stateSession.Profile
+			session.Tags = stateSession.Tags
+			session.Note = stateSession.Note
 			for _, p := range stateSession.Panes {
 				statePaneMap[p.TmuxID] = p
 			}
@@ -38,6 +46,10 @@ func (a *App) Snapshot() (domain.Snapshot, error) {
 			if sp, ok := statePaneMap[pane.ID]; ok {
 				pane.Title = sp.Title
 				pane.Type = domain.PaneType(sp.Type)
+				pane.Path = sp.Path
+				pane.Restarts = sp.Restarts
+				pane.Tags = sp.Tags
+				pane.Note = sp.Note
 			} else {
 				pane.Type = domain.InferPaneType(pane.CurrentCommand, pane.Title)
 			}
@@ -18,6 +18,44 @@ func (a *App) loadStateOrNew() *state.Store {
 	return st
 }
 
+// errUnchanged tells updateState there is nothing to save.
+var errUnchanged = errors.New("state unchanged")
+
+// updateState loads state, lets change modify it and saves it, holding the
+// state lock throughout so commands running at the same time (a dispatcher,
+// the supervisor, the dashboard) don't overwrite each other's changes. State
+// is not saved when change returns an error, or errUnchanged. change must
+// not call updateState itself.
+func (a *App) updateState(change func(*state.Store) error) error {
+	unlock, err := a.state.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	st := a.loadStateOrNew()
+	if err := change(st); err != nil {
+		if errors.Is(err, errUnchanged) {
+			return nil
+		}
+		return err
+	}
+	return a.state.Save(st)
+}
+
+// forgetSession removes a session from state. Pins are kept so the session
+// stays pinned if it is recreated.
+func (a *App) forgetSession(name string) error {
+	// The server ID is left as is: killing the last session stops the server.
+	return a.updateState(func(st *state.Store) error {
+		if _, ok := st.Sessions[name]; !ok {
+			return errUnchanged
+		}
+		delete(st.Sessions, name)
+		return nil
+	})
+}
+
 func (a *App) attachServerID(st *state.Store) error {
 	if st == nil {
 		return nil
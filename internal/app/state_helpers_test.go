@@ -0,0 +1,75 @@
+package app
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+func stateTestApp(t *testing.T) *App {
+	t.Helper()
+	return &App{
+		state:  state.NewStoreFile(filepath.Join(t.TempDir(), "state.yml")),
+		logger: log.New(os.Stderr, "", 0),
+	}
+}
+
+func TestUpdateStateSerializesWriters(t *testing.T) {
+	a := stateTestApp(t)
+
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := a.updateState(func(st *state.Store) error {
+				st.AddTask("repo", "prompt", "", time.Now())
+				return nil
+			})
+			if err != nil {
+				t.Errorf("updateState: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	st := a.loadStateOrNew()
+	if len(st.Tasks) != writers {
+		t.Fatalf("expected %d tasks, got %d", writers, len(st.Tasks))
+	}
+	seen := map[int]bool{}
+	for _, task := range st.Tasks {
+		if seen[task.ID] {
+			t.Fatalf("task id %d handed out twice", task.ID)
+		}
+		seen[task.ID] = true
+	}
+}
+
+func TestUpdateStateSkipsSave(t *testing.T) {
+	a := stateTestApp(t)
+	boom := errors.New("boom")
+
+	for _, want := range []error{errUnchanged, boom} {
+		err := a.updateState(func(st *state.Store) error {
+			st.Pinned = []string{"app"}
+			return want
+		})
+		if want == errUnchanged && err != nil {
+			t.Fatalf("expected errUnchanged to be swallowed, got %v", err)
+		}
+		if want == boom && !errors.Is(err, boom) {
+			t.Fatalf("expected the change error, got %v", err)
+		}
+		if _, statErr := os.Stat(a.state.Path()); !os.IsNotExist(statErr) {
+			t.Fatalf("expected no state written, got %v", statErr)
+		}
+	}
+}
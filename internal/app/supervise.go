@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+type SuperviseOptions struct {
+	// Session limits supervision to one session; empty means all sessions.
+	Session  string
+	Interval time.Duration
+	// Once runs a single check instead of looping until ctx is done.
+	Once bool
+}
+
+type SuperviseEvent struct {
+	Session string
+	PaneID  string
+	Title   string
+	// Action is "restarted", "gave-up" or "failed".
+	Action string
+	Reason string
+	Err    error
+}
+
+// Supervise enforces pane restart policies, reporting every restart or
+// failure to report. It blocks until ctx is cancelled unless opts.Once is set.
+func (a *App) Supervise(ctx context.Context, opts SuperviseOptions, report func(SuperviseEvent)) error {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+
+	// Only report giving up once per pane, until it is restarted again.
+	gaveUp := map[string]bool{}
+
+	for {
+		events, err := a.SuperviseOnce(opts.Session)
+		if err != nil {
+			if opts.Once {
+				return err
+			}
+			a.logger.Printf("supervise: %v", err)
+		}
+		for _, ev := range events {
+			switch ev.Action {
+			case "gave-up":
+				if gaveUp[ev.PaneID] {
+					continue
+				}
+				gaveUp[ev.PaneID] = true
+			case "restarted":
+				delete(gaveUp, ev.PaneID)
+			}
+			report(ev)
+		}
+		if opts.Once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// SuperviseOnce checks every supervised pane once and restarts exited agents
+// whose policy, retry budget and backoff allow it.
+func (a *App) SuperviseOnce(session string) ([]SuperviseEvent, error) {
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	st := a.loadStateOrNew()
+
+	var events []SuperviseEvent
+	var stable []string
+	now := time.Now()
+	for _, s := range snapshot.Sessions {
+		if session != "" && s.Name != session {
+			continue
+		}
+		ss := st.Sessions[s.Name]
+		if ss == nil {
+			continue
+		}
+		for _, pane := range s.Panes {
+			ps := findPaneState(ss, pane.ID)
+			if ps == nil || ps.RestartPolicy == nil {
+				continue
+			}
+			if pane.Status != domain.StatusExited {
+				if recovered(ps, now) {
+					stable = append(stable, pane.ID)
+				}
+				continue
+			}
+
+			reason, restart := a.shouldRestart(pane.ID, ps.RestartPolicy)
+			if !restart {
+				continue
+			}
+			ev := SuperviseEvent{Session: s.Name, PaneID: pane.ID, Title: pane.Title, Reason: reason}
+			switch restartVerdictFor(ps, now) {
+			case verdictWait:
+				continue
+			case verdictGiveUp:
+				ev.Action = "gave-up"
+				ev.Reason = fmt.Sprintf("reached max_restarts (%d without staying up for %s)", ps.RestartPolicy.MaxRestarts, stableAfter)
+				events = append(events, ev)
+				continue
+			}
+
+			if _, err := a.RestartPane(RestartOptions{Session: s.Name, PaneID: pane.ID, Reason: reason, Supervised: true}); err != nil {
+				ev.Action = "failed"
+				ev.Err = err
+			} else {
+				ev.Action = "restarted"
+			}
+			events = append(events, ev)
+		}
+	}
+
+	if len(stable) > 0 {
+		err := a.updateState(func(st *state.Store) error {
+			for _, ss := range st.Sessions {
+				for _, id := range stable {
+					if ps := findPaneState(ss, id); ps != nil {
+						ps.RetryStreak = 0
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return events, err
+		}
+	}
+	return events, nil
+}
+
+// stableAfter is how long a restarted pane must stay up for the supervisor
+// to consider it recovered and give it its max_restarts budget back. Until
+// then every supervised restart counts against it, however far apart.
+const stableAfter = 15 * time.Minute
+
+// startupGrace is how long a pane is left alone after it was created or
+// restarted, while the agent is still starting up.
+const startupGrace = 15 * time.Second
+
+type restartVerdict int
+
+const (
+	verdictWait restartVerdict = iota
+	verdictRestart
+	verdictGiveUp
+)
+
+// restartVerdictFor decides what to do with an exited pane whose policy
+// allows a restart: wait out the startup grace or backoff, restart it, or
+// give up because the retry budget is spent.
+func restartVerdictFor(ps *state.PaneState, now time.Time) restartVerdict {
+	if now.Sub(paneStarted(ps)) < startupGrace {
+		return verdictWait
+	}
+
+	streak := ps.RetryStreak
+	if streak >= ps.RestartPolicy.MaxRestarts {
+		return verdictGiveUp
+	}
+	if streak > 0 && ps.LastRestartAt != nil && now.Sub(*ps.LastRestartAt) < restartBackoff(ps.RestartPolicy, streak) {
+		return verdictWait
+	}
+	return verdictRestart
+}
+
+// recovered reports whether a running pane has stayed up long enough since
+// its last supervised restart for its retry streak to be reset.
+func recovered(ps *state.PaneState, now time.Time) bool {
+	return ps.RetryStreak > 0 && now.Sub(paneStarted(ps)) >= stableAfter
+}
+
+// paneStarted is when the pane's current run began: its last restart, or its
+// creation.
+func paneStarted(ps *state.PaneState) time.Time {
+	if ps.LastRestartAt != nil && ps.LastRestartAt.After(ps.CreatedAt) {
+		return *ps.LastRestartAt
+	}
+	return ps.CreatedAt
+}
+
+// shouldRestart reports whether an exited pane qualifies for a restart under
+// policy, with the reason recorded in the restart log.
+func (a *App) shouldRestart(paneID string, policy *state.RestartPolicy) (string, bool) {
+	status, _ := a.tmux.PaneOption(paneID, paneExitOption)
+	switch policy.Restart {
+	case config.RestartAlways:
+		if status == "" {
+			return "exited", true
+		}
+		return "exited with status " + status, true
+	case config.RestartOnFailure:
+		if status == "0" {
+			return "", false
+		}
+		// A missing status means the agent died without the wrapper
+		// reporting back (e.g. it was killed); treat that as a failure.
+		if status == "" {
+			return "exited without status", true
+		}
+		return "exited with status " + status, true
+	}
+	return "", false
+}
+
+func findPaneState(ss *state.SessionState, paneID string) *state.PaneState {
+	for _, p := range ss.Panes {
+		if p.TmuxID == paneID {
+			return p
+		}
+	}
+	return nil
+}
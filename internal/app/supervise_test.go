@@ -0,0 +1,150 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	policy := &state.RestartPolicy{Backoff: "10s"}
+	cases := []struct {
+		restarts int
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{4, 80 * time.Second},
+		{10, 5 * time.Minute},
+	}
+	for _, c := range cases {
+		if got := restartBackoff(policy, c.restarts); got != c.want {
+			t.Errorf("restartBackoff(10s, %d) = %s, want %s", c.restarts, got, c.want)
+		}
+	}
+
+	if got := restartBackoff(&state.RestartPolicy{Backoff: "bogus"}, 1); got != 5*time.Second {
+		t.Errorf("expected default backoff for an invalid duration, got %s", got)
+	}
+}
+
+func TestRestartVerdict(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	policy := &state.RestartPolicy{Restart: "always", MaxRestarts: 3, Backoff: "10s"}
+
+	cases := []struct {
+		name string
+		pane state.PaneState
+		want restartVerdict
+	}{
+		{
+			name: "still starting up",
+			pane: state.PaneState{CreatedAt: ago(5 * time.Second)},
+			want: verdictWait,
+		},
+		{
+			name: "exited after startup",
+			pane: state.PaneState{CreatedAt: ago(time.Minute)},
+			want: verdictRestart,
+		},
+		{
+			name: "manual restart just now",
+			pane: state.PaneState{
+				CreatedAt:     ago(time.Hour),
+				LastRestartAt: ptr(ago(2 * time.Second)),
+			},
+			want: verdictWait,
+		},
+		{
+			name: "within backoff",
+			pane: state.PaneState{
+				CreatedAt:     ago(time.Hour),
+				LastRestartAt: ptr(ago(17 * time.Second)),
+				RetryStreak:   2,
+			},
+			want: verdictWait,
+		},
+		{
+			name: "backoff elapsed",
+			pane: state.PaneState{
+				CreatedAt:     ago(time.Hour),
+				LastRestartAt: ptr(ago(25 * time.Second)),
+				RetryStreak:   2,
+			},
+			want: verdictRestart,
+		},
+		{
+			name: "budget spent",
+			pane: state.PaneState{
+				CreatedAt:     ago(time.Hour),
+				LastRestartAt: ptr(ago(time.Minute)),
+				RetryStreak:   3,
+			},
+			want: verdictGiveUp,
+		},
+		{
+			name: "budget stays spent after a long backoff",
+			pane: state.PaneState{
+				CreatedAt:     ago(3 * time.Hour),
+				LastRestartAt: ptr(ago(2 * time.Hour)),
+				RetryStreak:   3,
+			},
+			want: verdictGiveUp,
+		},
+		{
+			name: "manual restarts don't count",
+			pane: state.PaneState{
+				CreatedAt:     ago(time.Hour),
+				LastRestartAt: ptr(ago(time.Minute)),
+				Restarts:      10,
+			},
+			want: verdictRestart,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.pane.RestartPolicy = policy
+			if got := restartVerdictFor(&c.pane, now); got != c.want {
+				t.Fatalf("expected verdict %d, got %d", c.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryStreak(t *testing.T) {
+	start := time.Now()
+	ps := &state.PaneState{CreatedAt: start, RestartPolicy: &state.RestartPolicy{Restart: "always", MaxRestarts: 2}}
+
+	// A pane that crashes right after every start is given up on, however
+	// long the backoff between restarts gets.
+	at := start
+	for i := 0; i < 2; i++ {
+		at = at.Add(time.Hour)
+		if got := restartVerdictFor(ps, at); got != verdictRestart {
+			t.Fatalf("restart %d: expected restart, got %d", i+1, got)
+		}
+		ps.RecordRestart(at, "exited", true)
+		if recovered(ps, at.Add(time.Minute)) {
+			t.Fatalf("restart %d: pane that ran for a minute must not count as recovered", i+1)
+		}
+	}
+	if got := restartVerdictFor(ps, at.Add(time.Hour)); got != verdictGiveUp {
+		t.Fatalf("expected give up, got %d", got)
+	}
+
+	// Staying up for stableAfter earns the budget back.
+	if !recovered(ps, at.Add(stableAfter)) {
+		t.Fatalf("expected pane up for %s to count as recovered", stableAfter)
+	}
+
+	// So does a manual restart.
+	ps.RecordRestart(at.Add(time.Hour), "manual", false)
+	if ps.RetryStreak != 0 {
+		t.Fatalf("expected manual restart to reset the streak, got %d", ps.RetryStreak)
+	}
+}
+
+func ptr(t time.Time) *time.Time { return &t }
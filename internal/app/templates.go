@@ -1,21 +1,33 @@
 package app
 
 import (
+	"fmt"
 	"os"
 	"sort"
 
 	"github.com/minghinmatthewlam/agentpane/internal/config"
 )
 
+// Template sources, see TemplateSummary.Source.
+const (
+	TemplateBuiltin = "builtin"
+	TemplateGlobal  = "global"
+	TemplateRepo    = "repo"
+)
+
 type TemplateSummary struct {
 	Name        string
 	Description string
 	Panes       []config.PaneSpec
+	// Source is TemplateBuiltin, TemplateGlobal or TemplateRepo. Global and
+	// repo templates can be edited and deleted; editing a builtin saves a
+	// global override.
+	Source string
 }
 
 func (a *App) ListTemplates() ([]TemplateSummary, error) {
 	cwd, _ := os.Getwd()
-	loaded, err := config.LoadAll(cwd)
+	loaded, err := a.loadConfig(cwd)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +45,187 @@ func (a *App) ListTemplates() ([]TemplateSummary, error) {
 			Name:        name,
 			Description: tmpl.Description,
 			Panes:       tmpl.Panes,
+			Source:      templateSource(loaded, name),
 		})
 	}
 	return out, nil
 }
+
+func templateSource(loaded *config.Loaded, name string) string {
+	if loaded.Repo != nil {
+		if _, ok := loaded.Repo.Templates[name]; ok {
+			return TemplateRepo
+		}
+	}
+	if loaded.Global != nil {
+		if _, ok := loaded.Global.Templates[name]; ok {
+			return TemplateGlobal
+		}
+	}
+	return TemplateBuiltin
+}
+
+// RawTemplate returns a template as written in the config, before variables
+// are expanded, for editing.
+func (a *App) RawTemplate(name string) (config.Template, error) {
+	cwd, _ := os.Getwd()
+	loaded, err := a.loadConfig(cwd)
+	if err != nil {
+		return config.Template{}, err
+	}
+	if loaded.Repo != nil {
+		if tmpl, ok := loaded.Repo.Templates[name]; ok {
+			return tmpl, nil
+		}
+	}
+	if loaded.Global != nil {
+		if tmpl, ok := loaded.Global.Templates[name]; ok {
+			return tmpl, nil
+		}
+	}
+	if tmpl, ok := loaded.Builtins[name]; ok {
+		return tmpl, nil
+	}
+	return config.Template{}, fmt.Errorf("template %q not found", name)
+}
+
+// SaveTemplate writes a template to the config file it came from (the global
+// config for new templates and builtins), replacing any template of the same
+// name. When previous is set and differs from name, the template is being
+// renamed and the old entry is removed.
+func (a *App) SaveTemplate(name, previous string, tmpl config.Template) error {
+	if err := config.ValidateTemplate(name, tmpl); err != nil {
+		return err
+	}
+	source := name
+	if previous != "" {
+		source = previous
+	}
+	path, err := a.templateFile(source)
+	if err != nil {
+		return err
+	}
+	if err := config.SetTemplate(path, name, tmpl); err != nil {
+		return err
+	}
+	if previous != "" && previous != name {
+		if _, err := config.RemoveTemplate(path, previous); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTemplate removes a template from the config file defining it.
+// Builtin templates cannot be deleted; deleting an override of one restores
+// the builtin.
+func (a *App) DeleteTemplate(name string) error {
+	path, err := a.templateFile(name)
+	if err != nil {
+		return err
+	}
+	removed, err := config.RemoveTemplate(path, name)
+	if err != nil {
+		return err
+	}
+	if removed {
+		return nil
+	}
+	builtins, err := config.LoadBuiltinTemplates()
+	if err != nil {
+		return err
+	}
+	if _, ok := builtins[name]; ok {
+		return fmt.Errorf("template %q is built in and cannot be deleted", name)
+	}
+	return fmt.Errorf("template %q not found in %s", name, path)
+}
+
+// templateFile is the config file that defines the named template: the repo
+// config for repo templates, otherwise the global config.
+func (a *App) templateFile(name string) (string, error) {
+	cwd, _ := os.Getwd()
+	loaded, err := a.loadConfig(cwd)
+	if err != nil {
+		return "", err
+	}
+	if loaded.Repo != nil {
+		if _, ok := loaded.Repo.Templates[name]; ok {
+			return loaded.RepoPath, nil
+		}
+	}
+	return config.GlobalConfigPath()
+}
+
+type SaveCurrentOptions struct {
+	Name string
+	// Session defaults to the current tmux session.
+	Session string
+	// Repo writes to the session repo's .agentpane.yml instead of the global
+	// config.
+	Repo bool
+	// Force overwrites an existing template of the same name.
+	Force bool
+}
+
+type SaveCurrentResult struct {
+	Name     string
+	Path     string
+	Template config.Template
+}
+
+// SaveCurrentAsTemplate captures a running session's panes and layout as a
+// named template usable with `up --template`.
+func (a *App) SaveCurrentAsTemplate(opts SaveCurrentOptions) (SaveCurrentResult, error) {
+	session := opts.Session
+	if session == "" {
+		if !a.tmux.InTmux() {
+			return SaveCurrentResult{}, fmt.Errorf("session is required when not inside tmux")
+		}
+		var err error
+		if session, err = a.tmux.CurrentSession(); err != nil {
+			return SaveCurrentResult{}, err
+		}
+	}
+
+	tmpl, err := a.captureSession(session)
+	if err != nil {
+		return SaveCurrentResult{}, err
+	}
+	tmpl.Description = fmt.Sprintf("Saved from session %s", session)
+	if err := config.ValidateTemplate(opts.Name, tmpl); err != nil {
+		return SaveCurrentResult{}, err
+	}
+
+	sessionPath, _ := a.tmux.SessionPath(session)
+	loaded, err := a.loadConfig(sessionPath)
+	if err != nil {
+		return SaveCurrentResult{}, err
+	}
+	if _, exists := loaded.Merged.Templates[opts.Name]; exists && !opts.Force {
+		return SaveCurrentResult{}, fmt.Errorf("template %q already exists; use --force to overwrite it", opts.Name)
+	}
+
+	var path string
+	if opts.Repo {
+		path = a.repoConfigPath(loaded, sessionPath)
+	} else if path, err = config.GlobalConfigPath(); err != nil {
+		return SaveCurrentResult{}, err
+	}
+	if err := config.SetTemplate(path, opts.Name, tmpl); err != nil {
+		return SaveCurrentResult{}, err
+	}
+	return SaveCurrentResult{Name: opts.Name, Path: path, Template: tmpl}, nil
+}
+
+// repoConfigPath is the repo config for a directory: the existing
+// .agentpane.yml found from it, or a new one at the git root.
+func (a *App) repoConfigPath(loaded *config.Loaded, dir string) string {
+	if loaded.Repo != nil && loaded.RepoPath != "" {
+		return loaded.RepoPath
+	}
+	if st, ok := a.git.Status(dir); ok && st.Root != "" {
+		return config.RepoConfigPath(st.Root)
+	}
+	return config.RepoConfigPath(dir)
+}
@@ -23,6 +23,11 @@ type UpOptions struct {
 	ExplicitName string
 	Template     string
 	Detach       bool
+	// Workspace creates one session spanning the repos of a named workspace.
+	Workspace string
+	// NoPrompt picks the next free name on a collision instead of asking on
+	// stdin, for callers that own the terminal such as the dashboard.
+	NoPrompt bool
 }
 
 type UpAction string
@@ -42,7 +47,11 @@ type UpResult struct {
 }
 
 func (a *App) Up(opts UpOptions) (UpResult, error) {
-	loaded, err := config.LoadAll(opts.Cwd)
+	if opts.Workspace != "" {
+		return a.upWorkspace(opts)
+	}
+
+	loaded, err := a.loadConfig(opts.Cwd)
 	if err != nil {
 		return UpResult{}, err
 	}
@@ -54,7 +63,7 @@ func (a *App) Up(opts UpOptions) (UpResult, error) {
 		baseOverride = strings.TrimSpace(loaded.Repo.Session)
 	}
 
-	sessionName, err := a.resolveSessionName(opts.Cwd, opts.ExplicitName, baseOverride)
+	sessionName, err := a.resolveSessionName(opts.Cwd, opts.ExplicitName, baseOverride, opts.NoPrompt)
 	if err != nil {
 		return UpResult{}, err
 	}
@@ -73,44 +82,122 @@ func (a *App) Up(opts UpOptions) (UpResult, error) {
 	}
 
 	if !exists {
-		panes, err := resolvePanes(loaded, opts.Template)
+		panes, layout, err := resolvePanes(loaded, opts.Template)
 		if err != nil {
 			return UpResult{}, err
 		}
-		warnings, err := a.createSessionFromPanes(sessionName, opts.Cwd, panes)
+		warnings, err := a.createSessionFromPanes(sessionName, opts.Cwd, panes, layout, loaded)
 		if err != nil {
 			return UpResult{}, err
 		}
-		if err := a.Reconcile(); err != nil {
-			return UpResult{}, err
+		return a.finishCreatedSession(sessionName, opts.Detach, warnings)
+	}
+
+	return a.attachExistingSession(sessionName, opts.Detach)
+}
+
+// upWorkspace creates a single session whose panes start in the repos listed
+// by the workspace. Panes are laid out in workspace order.
+func (a *App) upWorkspace(opts UpOptions) (UpResult, error) {
+	ws, err := config.LoadWorkspace(opts.Workspace)
+	if err != nil {
+		return UpResult{}, err
+	}
+
+	name := opts.ExplicitName
+	if name == "" {
+		name = strings.TrimSpace(ws.Session)
+	}
+	if name == "" {
+		name = opts.Workspace
+	}
+	if !sessionNameRe.MatchString(name) {
+		name = sanitizeSessionName(name)
+	}
+
+	if a.tmux.InTmux() {
+		current, err := a.tmux.CurrentSession()
+		if err == nil && current == name {
+			return UpResult{Action: ActionAlreadyIn, SessionName: name}, nil
 		}
+	}
 
-		// Ensure dashboard keybinding is installed
-		keybindingAdded, _ := a.EnsureKeybinding()
+	exists, err := a.tmux.HasSession(name)
+	if err != nil {
+		return UpResult{}, err
+	}
+	if exists {
+		return a.attachExistingSession(name, opts.Detach)
+	}
+
+	// Global settings (profile, env, providers) don't depend on any one
+	// repo; the session starts in the first repo's directory.
+	first := ws.Repos[0].Path
+	loaded, err := config.LoadGlobalWithProfile(first, a.profile)
+	if err != nil {
+		return UpResult{}, err
+	}
+	a.applyProviderOverrides(loaded.Merged)
 
-		if opts.Detach {
-			return UpResult{Action: ActionDetached, SessionName: sessionName, Warnings: warnings, KeybindingAdded: keybindingAdded}, nil
+	var panes []config.PaneSpec
+	for _, repo := range ws.Repos {
+		specs := repo.Panes
+		if len(specs) == 0 {
+			repoLoaded, err := a.loadConfig(repo.Path)
+			if err != nil {
+				return UpResult{}, fmt.Errorf("workspace repo %s: %w", repo.Path, err)
+			}
+			specs, _, err = resolvePanes(repoLoaded, opts.Template)
+			if err != nil {
+				return UpResult{}, fmt.Errorf("workspace repo %s: %w", repo.Path, err)
+			}
 		}
-		if err := a.Attach(sessionName); err != nil {
-			return UpResult{}, err
+		for _, spec := range specs {
+			spec.Path = repo.Path
+			panes = append(panes, spec)
 		}
-		return UpResult{Action: ActionCreated, SessionName: sessionName, Warnings: warnings, KeybindingAdded: keybindingAdded}, nil
 	}
 
-	// Session exists.
+	warnings, err := a.createSessionFromPanes(name, first, panes, "", loaded)
+	if err != nil {
+		return UpResult{}, err
+	}
+	return a.finishCreatedSession(name, opts.Detach, warnings)
+}
+
+func (a *App) finishCreatedSession(name string, detach bool, warnings []string) (UpResult, error) {
+	if err := a.Reconcile(); err != nil {
+		return UpResult{}, err
+	}
+
+	// Ensure dashboard keybinding is installed
+	keybindingAdded, _ := a.EnsureKeybinding()
+
+	if detach {
+		return UpResult{Action: ActionDetached, SessionName: name, Warnings: warnings, KeybindingAdded: keybindingAdded}, nil
+	}
+	if err := a.Attach(name); err != nil {
+		return UpResult{}, err
+	}
+	return UpResult{Action: ActionCreated, SessionName: name, Warnings: warnings, KeybindingAdded: keybindingAdded}, nil
+}
+
+func (a *App) attachExistingSession(name string, detach bool) (UpResult, error) {
 	if err := a.Reconcile(); err != nil {
 		return UpResult{}, err
 	}
-	if opts.Detach {
-		return UpResult{Action: ActionDetached, SessionName: sessionName}, nil
+	if detach {
+		return UpResult{Action: ActionDetached, SessionName: name}, nil
 	}
-	if err := a.Attach(sessionName); err != nil {
+	if err := a.Attach(name); err != nil {
 		return UpResult{}, err
 	}
-	return UpResult{Action: ActionAttached, SessionName: sessionName}, nil
+	return UpResult{Action: ActionAttached, SessionName: name}, nil
 }
 
-func resolvePanes(loaded *config.Loaded, explicitTemplate string) ([]config.PaneSpec, error) {
+// resolvePanes picks the panes for a new session and the template's tmux
+// layout, if it has one.
+func resolvePanes(loaded *config.Loaded, explicitTemplate string) ([]config.PaneSpec, string, error) {
 	if explicitTemplate != "" {
 		tmpl, ok := loaded.Merged.Templates[explicitTemplate]
 		if !ok {
@@ -119,26 +206,26 @@ func resolvePanes(loaded *config.Loaded, explicitTemplate string) ([]config.Pane
 				names = append(names, k)
 			}
 			sort.Strings(names)
-			return nil, fmt.Errorf("unknown template %q (available: %s)", explicitTemplate, strings.Join(names, ", "))
+			return nil, "", fmt.Errorf("unknown template %q (available: %s)", explicitTemplate, strings.Join(names, ", "))
 		}
-		return tmpl.Panes, nil
+		return tmpl.Panes, tmpl.Layout, nil
 	}
 
 	if loaded.Repo != nil && len(loaded.Repo.Layout.Panes) > 0 {
-		return loaded.Repo.Layout.Panes, nil
+		return loaded.Repo.Layout.Panes, "", nil
 	}
 
 	if loaded.Merged.DefaultTemplate != "" {
 		if tmpl, ok := loaded.Merged.Templates[loaded.Merged.DefaultTemplate]; ok {
-			return tmpl.Panes, nil
+			return tmpl.Panes, tmpl.Layout, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no panes resolved (missing templates and repo layout)")
+	return nil, "", fmt.Errorf("no panes resolved (missing templates and repo layout)")
 }
 
-func (a *App) createSessionFromPanes(name, cwd string, panes []config.PaneSpec) ([]string, error) {
-	if err := a.tmux.NewSession(name, cwd); err != nil {
+func (a *App) createSessionFromPanes(name, cwd string, panes []config.PaneSpec, layout string, loaded *config.Loaded) ([]string, error) {
+	if err := a.tmux.NewSession(name, cwd, loaded.Merged.Env); err != nil {
 		return nil, err
 	}
 
@@ -173,19 +260,24 @@ func (a *App) createSessionFromPanes(name, cwd string, panes []config.PaneSpec)
 	}
 	warnings = append(warnings, firstRes.Warnings...)
 	paneStates = append(paneStates, &state.PaneState{
-		TmuxID:    firstPaneID,
-		Type:      string(firstRes.Type),
-		Title:     firstRes.Title,
-		CreatedAt: now,
+		TmuxID:        firstPaneID,
+		Type:          string(firstRes.Type),
+		Title:         firstRes.Title,
+		Path:          panePath(cwd, panes[0]),
+		Command:       firstRes.Command,
+		SpecCommand:   firstRes.SpecCommand,
+		CreatedAt:     now,
+		RestartPolicy: firstRes.RestartPolicy,
 	})
 
 	for i := 1; i < len(panes); i++ {
 		var newPaneID string
 		var err error
+		dir := panePath(cwd, panes[i])
 		if len(panes) == 2 {
-			newPaneID, err = a.tmux.SplitPaneHorizontal(name, cwd)
+			newPaneID, err = a.tmux.SplitPaneHorizontal(name, dir)
 		} else {
-			newPaneID, err = a.tmux.SplitPane(name, cwd)
+			newPaneID, err = a.tmux.SplitPane(name, dir)
 		}
 		if err != nil {
 			return nil, err
@@ -196,26 +288,49 @@ func (a *App) createSessionFromPanes(name, cwd string, panes []config.PaneSpec)
 		}
 		warnings = append(warnings, res.Warnings...)
 		paneStates = append(paneStates, &state.PaneState{
-			TmuxID:    newPaneID,
-			Type:      string(res.Type),
-			Title:     res.Title,
-			CreatedAt: now,
+			TmuxID:        newPaneID,
+			Type:          string(res.Type),
+			Title:         res.Title,
+			Path:          dir,
+			Command:       res.Command,
+			SpecCommand:   res.SpecCommand,
+			CreatedAt:     now,
+			RestartPolicy: res.RestartPolicy,
 		})
 	}
 
-	layout := "tiled"
-	if len(panes) == 2 {
-		layout = "even-horizontal"
-	}
-	_ = a.tmux.SelectLayout(name, layout)
+	a.selectLayout(name, layout, len(panes))
 
-	if err := a.replaceSessionState(name, cwd, paneStates); err != nil {
+	if err := a.replaceSessionState(name, cwd, loaded.Profile, paneStates); err != nil {
 		return nil, err
 	}
 
 	return warnings, nil
 }
 
+// selectLayout arranges a session's panes with a template's layout, falling
+// back to tiled (even-horizontal for two panes) when it has none or tmux
+// rejects it, e.g. a saved layout string for a different number of panes.
+func (a *App) selectLayout(session, layout string, panes int) {
+	if layout != "" && a.tmux.SelectLayout(session, layout) == nil {
+		return
+	}
+	fallback := "tiled"
+	if panes == 2 {
+		fallback = "even-horizontal"
+	}
+	_ = a.tmux.SelectLayout(session, fallback)
+}
+
+// panePath returns the directory a pane starts in: its own path when set
+// (workspace panes), otherwise the session directory.
+func panePath(sessionCwd string, spec config.PaneSpec) string {
+	if spec.Path != "" {
+		return spec.Path
+	}
+	return sessionCwd
+}
+
 func (a *App) launchProvider(paneID string, prov *provider.Provider) error {
 	if prov.Command == "" {
 		// For shell panes, send clear to remove any garbage from mouse events
@@ -233,9 +348,13 @@ func (a *App) launchProvider(paneID string, prov *provider.Provider) error {
 }
 
 type paneConfigResult struct {
-	Type     domain.PaneType
-	Title    string
-	Warnings []string
+	Type    domain.PaneType
+	Title   string
+	Command string
+	// SpecCommand is the unexpanded command of the spec, if it had one.
+	SpecCommand   string
+	RestartPolicy *state.RestartPolicy
+	Warnings      []string
 }
 
 func (a *App) configurePaneSpec(paneID string, spec config.PaneSpec, typeCounts map[domain.PaneType]int) (paneConfigResult, error) {
@@ -260,22 +379,35 @@ func (a *App) configurePaneSpec(paneID string, spec config.PaneSpec, typeCounts
 		title = fmt.Sprintf("%s%d", prov.TitlePrefix, typeCounts[actualType])
 	}
 
+	policy := a.restartPolicyFor(actualType, spec.RestartPolicy)
+
+	specCommand := ""
+	if spec.Command != "" && actualType == desired {
+		custom := *prov
+		custom.Command = spec.Command
+		prov = &custom
+		specCommand = spec.RawCommand
+	}
+
 	if err := a.tmux.SetPaneTitle(paneID, title); err != nil {
 		return paneConfigResult{}, err
 	}
-	if err := a.launchProvider(paneID, prov); err != nil {
+	if err := a.launchProvider(paneID, withRestartPolicy(prov, policy)); err != nil {
 		return paneConfigResult{}, err
 	}
 	return paneConfigResult{
-		Type:     actualType,
-		Title:    title,
-		Warnings: warnings,
+		Type:          actualType,
+		Title:         title,
+		Command:       prov.Command,
+		SpecCommand:   specCommand,
+		RestartPolicy: policy,
+		Warnings:      warnings,
 	}, nil
 }
 
 var sessionNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
 
-func (a *App) resolveSessionName(cwd string, explicit string, baseOverride string) (string, error) {
+func (a *App) resolveSessionName(cwd string, explicit string, baseOverride string, noPrompt bool) (string, error) {
 	if explicit != "" {
 		if !sessionNameRe.MatchString(explicit) {
 			return "", fmt.Errorf("invalid session name %q (use letters, numbers, dot, underscore, dash)", explicit)
@@ -306,6 +438,9 @@ func (a *App) resolveSessionName(cwd string, explicit string, baseOverride strin
 	}
 
 	// Collision: prompt for an alternate name if interactive, else error.
+	if noPrompt {
+		return a.firstAvailableSuffix(base)
+	}
 	if !stdinIsTTY() {
 		if existingPath == "" {
 			existingPath = "unknown"
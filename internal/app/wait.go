@@ -0,0 +1,260 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+)
+
+// Pane states a wait can block on. Idle agents are running but their output
+// has not changed for WaitOptions.IdleFor; active ones have changed it since
+// the wait started.
+const (
+	WaitActive = "active"
+	WaitIdle   = "idle"
+	WaitExited = "exited"
+)
+
+// WaitUnknown is reported for a pane whose output has neither changed nor
+// stayed still for IdleFor yet, so it is neither active nor idle.
+const WaitUnknown = "unknown"
+
+// Conditions reported in WaitResult.Condition.
+const (
+	ConditionStatus  = "status"
+	ConditionMatch   = "match"
+	ConditionTimeout = "timeout"
+)
+
+// DefaultWaitIdle is how long output must be unchanged for a pane to be idle.
+const DefaultWaitIdle = 30 * time.Second
+
+const waitInterval = 500 * time.Millisecond
+
+type WaitOptions struct {
+	// Panes are explicit targets. When empty, the panes of Session are used.
+	Panes   []PaneRef
+	Session string
+	// Type limits the targets to one pane type.
+	Type domain.PaneType
+
+	// Status is WaitActive, WaitIdle or WaitExited, empty for none.
+	Status string
+	// Match is a pattern the pane's visible output must match, nil for none.
+	Match *regexp.Regexp
+	// Any is satisfied by a single pane instead of every target.
+	Any bool
+
+	IdleFor time.Duration
+	// Timeout gives up after this long; zero waits forever.
+	Timeout time.Duration
+}
+
+type WaitResult struct {
+	// Condition is the condition that fired, or ConditionTimeout.
+	Condition string     `json:"condition"`
+	Elapsed   float64    `json:"elapsed_seconds"`
+	Panes     []WaitPane `json:"panes"`
+}
+
+// TimedOut reports whether the wait gave up before a condition fired.
+func (r WaitResult) TimedOut() bool { return r.Condition == ConditionTimeout }
+
+// WaitPane is a target pane as last seen.
+type WaitPane struct {
+	Session string `json:"session"`
+	PaneID  string `json:"pane_id"`
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	// ExitStatus is set when a wrapped command recorded how it exited.
+	ExitStatus *int `json:"exit_status,omitempty"`
+	// Match is the text the pattern matched, if it did.
+	Match string `json:"match,omitempty"`
+	// Met reports whether this pane satisfied the condition that fired.
+	Met bool `json:"met"`
+
+	matched bool
+	// first is the content seen on the first observation; changed is set
+	// once the content differs from it.
+	first   *string
+	changed bool
+}
+
+// Wait blocks until every target pane (or any, with Any) reaches Status or
+// shows output matching Match, whichever comes first.
+func (a *App) Wait(opts WaitOptions) (WaitResult, error) {
+	if opts.Status == "" && opts.Match == nil {
+		return WaitResult{}, fmt.Errorf("nothing to wait for (use --status or --match)")
+	}
+	switch opts.Status {
+	case "", WaitActive, WaitIdle, WaitExited:
+	default:
+		return WaitResult{}, fmt.Errorf("unknown status %q (use active, idle or exited)", opts.Status)
+	}
+	if opts.IdleFor <= 0 {
+		opts.IdleFor = DefaultWaitIdle
+	}
+
+	targets, err := a.waitTargets(opts)
+	if err != nil {
+		return WaitResult{}, err
+	}
+
+	start := time.Now()
+	tracker := provider.NewActivityTracker(opts.IdleFor)
+	for {
+		snapshot, err := a.Snapshot()
+		if err != nil {
+			return WaitResult{}, err
+		}
+		now := time.Now()
+		a.observeTargets(targets, snapshot, tracker, opts.Match, now)
+
+		result := WaitResult{Elapsed: now.Sub(start).Seconds()}
+		if opts.Status != "" && markMet(targets, opts.Any, func(p *WaitPane) bool { return p.Status == opts.Status }) {
+			result.Condition = ConditionStatus
+		} else if opts.Match != nil && markMet(targets, opts.Any, func(p *WaitPane) bool { return p.matched }) {
+			result.Condition = ConditionMatch
+		} else if opts.Timeout > 0 && now.Sub(start) >= opts.Timeout {
+			result.Condition = ConditionTimeout
+		}
+		if result.Condition != "" {
+			result.Panes = targets
+			return result, nil
+		}
+		time.Sleep(waitInterval)
+	}
+}
+
+func (a *App) waitTargets(opts WaitOptions) ([]WaitPane, error) {
+	var targets []WaitPane
+	add := func(session string, p domain.Pane) {
+		if opts.Type != "" && p.Type != opts.Type {
+			return
+		}
+		targets = append(targets, WaitPane{Session: session, PaneID: p.ID, Title: p.Title, Type: string(p.Type)})
+	}
+
+	snapshot, err := a.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Panes) > 0 {
+		for _, ref := range opts.Panes {
+			for _, s := range snapshot.Sessions {
+				for _, p := range s.Panes {
+					if p.ID == ref.PaneID {
+						add(s.Name, p)
+					}
+				}
+			}
+		}
+	} else {
+		session := opts.Session
+		if session == "" {
+			session = snapshot.CurrentSession
+		}
+		if session == "" {
+			return nil, fmt.Errorf("no panes given (pass panes or --session)")
+		}
+		found := false
+		for _, s := range snapshot.Sessions {
+			if s.Name != session {
+				continue
+			}
+			found = true
+			for _, p := range s.Panes {
+				add(s.Name, p)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("session %q not found", session)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no matching panes")
+	}
+	return targets, nil
+}
+
+// observeTargets refreshes each target's status and match. Panes that have
+// gone away count as exited.
+func (a *App) observeTargets(targets []WaitPane, snapshot domain.Snapshot, tracker *provider.ActivityTracker, match *regexp.Regexp, now time.Time) {
+	live := map[string]domain.Pane{}
+	for _, s := range snapshot.Sessions {
+		for _, p := range s.Panes {
+			live[p.ID] = p
+		}
+	}
+
+	for i := range targets {
+		t := &targets[i]
+		p, ok := live[t.PaneID]
+		if !ok {
+			t.Status = WaitExited
+			continue
+		}
+		if code, err := a.tmux.PaneOption(p.ID, paneExitOption); err == nil && code != "" {
+			if n, err := strconv.Atoi(code); err == nil {
+				t.ExitStatus = &n
+			}
+		}
+
+		content, err := a.tmux.CapturePaneContent(p.ID)
+		if err != nil {
+			t.Status = WaitExited
+			continue
+		}
+		t.observe(p, content, tracker, match, now)
+	}
+}
+
+// observe updates a live target from its pane and captured content. A pane
+// only counts as active once its content changes during the wait, since a
+// single capture can't tell a working agent from a waiting one.
+func (t *WaitPane) observe(p domain.Pane, content string, tracker *provider.ActivityTracker, match *regexp.Regexp, now time.Time) {
+	if t.first == nil {
+		t.first = &content
+	} else if content != *t.first {
+		t.changed = true
+	}
+	idle := tracker.Observe(p.ID, content, now)
+	switch {
+	case p.Status == domain.StatusExited || t.ExitStatus != nil:
+		t.Status = WaitExited
+	case idle:
+		t.Status = WaitIdle
+	case t.changed:
+		t.Status = WaitActive
+	default:
+		t.Status = WaitUnknown
+	}
+
+	t.Match, t.matched = "", false
+	if match != nil {
+		if loc := match.FindStringIndex(content); loc != nil {
+			t.Match, t.matched = content[loc[0]:loc[1]], true
+		}
+	}
+}
+
+// markMet sets Met on the targets satisfying cond and reports whether all of
+// them do, or any of them with anyPane.
+func markMet(targets []WaitPane, anyPane bool, cond func(*WaitPane) bool) bool {
+	met := 0
+	for i := range targets {
+		targets[i].Met = cond(&targets[i])
+		if targets[i].Met {
+			met++
+		}
+	}
+	if anyPane {
+		return met > 0
+	}
+	return met == len(targets)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/provider"
+)
+
+func TestWaitPaneObserve(t *testing.T) {
+	start := time.Now()
+	idleFor := 10 * time.Second
+	pane := domain.Pane{ID: "%1", Status: domain.StatusActive}
+
+	steps := []struct {
+		name    string
+		after   time.Duration
+		content string
+		want    string
+	}{
+		{name: "first capture", after: 0, content: "> ", want: WaitUnknown},
+		{name: "unchanged", after: 2 * time.Second, content: "> ", want: WaitUnknown},
+		{name: "still past idle-for", after: idleFor, content: "> ", want: WaitIdle},
+		{name: "changed", after: idleFor + time.Second, content: "> fix the tests", want: WaitActive},
+		{name: "unchanged after change", after: idleFor + 2*time.Second, content: "> fix the tests", want: WaitActive},
+		{name: "back to first content", after: idleFor + 3*time.Second, content: "> ", want: WaitActive},
+		{name: "idle again", after: 2*idleFor + 3*time.Second, content: "> ", want: WaitIdle},
+	}
+
+	tracker := provider.NewActivityTracker(idleFor)
+	target := WaitPane{PaneID: pane.ID}
+	for _, s := range steps {
+		target.observe(pane, s.content, tracker, nil, start.Add(s.after))
+		if target.Status != s.want {
+			t.Fatalf("%s: expected %s, got %s", s.name, s.want, target.Status)
+		}
+	}
+}
+
+func TestWaitPaneObserveExitedAndMatch(t *testing.T) {
+	tracker := provider.NewActivityTracker(time.Second)
+	now := time.Now()
+	re := regexp.MustCompile(`\d+ passed`)
+
+	target := WaitPane{PaneID: "%1"}
+	target.observe(domain.Pane{ID: "%1", Status: domain.StatusActive}, "12 passed", tracker, re, now)
+	if !target.matched || target.Match != "12 passed" {
+		t.Fatalf("expected match, got %+v", target)
+	}
+	target.observe(domain.Pane{ID: "%1", Status: domain.StatusActive}, "running", tracker, re, now)
+	if target.matched || target.Match != "" {
+		t.Fatalf("expected match cleared, got %+v", target)
+	}
+
+	code := 1
+	target.ExitStatus = &code
+	target.observe(domain.Pane{ID: "%1", Status: domain.StatusActive}, "running", tracker, re, now)
+	if target.Status != WaitExited {
+		t.Fatalf("expected exited with an exit status, got %s", target.Status)
+	}
+	exited := WaitPane{PaneID: "%2"}
+	exited.observe(domain.Pane{ID: "%2", Status: domain.StatusExited}, "", tracker, nil, now)
+	if exited.Status != WaitExited {
+		t.Fatalf("expected exited pane, got %s", exited.Status)
+	}
+}
+
+func TestMarkMet(t *testing.T) {
+	isIdle := func(p *WaitPane) bool { return p.Status == WaitIdle }
+	cases := []struct {
+		name     string
+		statuses []string
+		anyPane  bool
+		want     bool
+		wantMet  []bool
+	}{
+		{name: "all met", statuses: []string{WaitIdle, WaitIdle}, want: true, wantMet: []bool{true, true}},
+		{name: "some met", statuses: []string{WaitIdle, WaitActive}, want: false, wantMet: []bool{true, false}},
+		{name: "any met", statuses: []string{WaitUnknown, WaitIdle}, anyPane: true, want: true, wantMet: []bool{false, true}},
+		{name: "any none met", statuses: []string{WaitUnknown, WaitActive}, anyPane: true, want: false, wantMet: []bool{false, false}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			targets := make([]WaitPane, len(c.statuses))
+			for i, s := range c.statuses {
+				// Met from an earlier round must be reset.
+				targets[i] = WaitPane{Status: s, Met: true}
+			}
+			if got := markMet(targets, c.anyPane, isIdle); got != c.want {
+				t.Fatalf("expected %v, got %v", c.want, got)
+			}
+			for i, want := range c.wantMet {
+				if targets[i].Met != want {
+					t.Fatalf("target %d: expected Met %v, got %v", i, want, targets[i].Met)
+				}
+			}
+		})
+	}
+}
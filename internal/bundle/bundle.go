@@ -0,0 +1,145 @@
+// Package bundle reads and writes portable session bundles: a single YAML
+// file describing a session's panes, layout, repo and optionally transcripts,
+// used by `agentpane export` and `agentpane import`.
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+)
+
+// Version is the bundle format version written by Marshal.
+const Version = 1
+
+type Bundle struct {
+	Version    int       `yaml:"version"`
+	Session    string    `yaml:"session"`
+	ExportedAt time.Time `yaml:"exported_at"`
+	Profile    string    `yaml:"profile,omitempty"`
+	Repo       Repo      `yaml:"repo"`
+	// Template holds the panes (type, title, command) and tmux layout.
+	Template config.Template `yaml:"template"`
+	// Env is the session environment from the config. Secret values are
+	// replaced by references to the same variable, see Redact.
+	Env         map[string]string `yaml:"env,omitempty"`
+	Transcripts []Transcript      `yaml:"transcripts,omitempty"`
+}
+
+// Repo records where the session ran so it can be found on another machine.
+type Repo struct {
+	Path   string `yaml:"path"`
+	Remote string `yaml:"remote,omitempty"`
+	Branch string `yaml:"branch,omitempty"`
+	Head   string `yaml:"head,omitempty"`
+}
+
+// Transcript is the scrollback of one pane at export time.
+type Transcript struct {
+	Title   string `yaml:"title"`
+	Content string `yaml:"content"`
+}
+
+func Marshal(b *Bundle) ([]byte, error) {
+	b.Version = Version
+	return yaml.Marshal(b)
+}
+
+func Load(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if b.Version == 0 || b.Version > Version {
+		return nil, fmt.Errorf("%s: unsupported bundle version %d", path, b.Version)
+	}
+	if len(b.Template.Panes) == 0 {
+		return nil, fmt.Errorf("%s: bundle has no panes", path)
+	}
+	if err := config.ValidateTemplate("bundle", b.Template); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// Placeholder replaces secrets that cannot be turned into a variable
+// reference.
+const Placeholder = "<redacted>"
+
+var (
+	secretName = regexp.MustCompile(`(?i)(key|token|secret|passw(or)?d|auth|credential|cookie|session_?id)`)
+	// --api-key=value, --token value. A following word that starts with "-"
+	// is the next flag, not a value: --no-auth --model opus.
+	secretFlag = regexp.MustCompile(`(?i)(--?[a-z0-9-]*(?:key|token|secret|password|passwd|auth)[a-z0-9-]*)(?:(=)("[^"]*"|'[^']*'|\S+)|(\s+)("[^"]*"|'[^']*'|[^\s-]\S*))`)
+	// FOO_TOKEN=value
+	secretAssign = regexp.MustCompile(`(?i)\b([A-Z0-9_]*(?:KEY|TOKEN|SECRET|PASSWORD|PASSWD|AUTH)[A-Z0-9_]*)=("[^"]*"|'[^']*'|\S+)`)
+	// Well-known credential shapes: sk-..., ghp_..., xoxb-..., AKIA...
+	secretValue = regexp.MustCompile(`\b(sk-[A-Za-z0-9_-]{16,}|gh[pousr]_[A-Za-z0-9]{20,}|xox[abpr]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16})\b`)
+)
+
+// IsSecretName reports whether an environment variable name looks like it
+// holds a credential.
+func IsSecretName(name string) bool {
+	return secretName.MatchString(name)
+}
+
+// RedactEnv returns env with secret values replaced by "${NAME}", so the
+// importing side picks the value up from its own environment. Values that
+// already are references are kept.
+func RedactEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		switch {
+		case strings.HasPrefix(v, "${") && strings.HasSuffix(v, "}"):
+			out[k] = v
+		case IsSecretName(k):
+			out[k] = "${" + k + "}"
+		default:
+			out[k] = RedactText(v)
+		}
+	}
+	return out
+}
+
+// RedactText masks credentials in a command line or transcript: values of
+// secret-looking flags and assignments, and well-known token formats.
+// References like $TOKEN or ${TOKEN} are left alone.
+func RedactText(s string) string {
+	s = secretFlag.ReplaceAllStringFunc(s, func(m string) string {
+		parts := secretFlag.FindStringSubmatch(m)
+		sep, value := parts[2], parts[3]
+		if sep == "" {
+			sep, value = parts[4], parts[5]
+		}
+		if isReference(value) {
+			return m
+		}
+		return parts[1] + sep + Placeholder
+	})
+	s = secretAssign.ReplaceAllStringFunc(s, func(m string) string {
+		parts := secretAssign.FindStringSubmatch(m)
+		if isReference(parts[2]) {
+			return m
+		}
+		return parts[1] + "=" + Placeholder
+	})
+	return secretValue.ReplaceAllString(s, Placeholder)
+}
+
+func isReference(v string) bool {
+	v = strings.Trim(v, `"'`)
+	return strings.HasPrefix(v, "$") || v == Placeholder
+}
@@ -12,9 +12,10 @@ func NewAddCmd(a *app.App) *cobra.Command {
 	var title string
 
 	cmd := &cobra.Command{
-		Use:   "add <codex|claude|shell>",
-		Short: "Add a pane to the current tmux session",
-		Args:  cobra.ExactArgs(1),
+		Use:               "add <codex|claude|shell>",
+		Short:             "Add a pane to the current tmux session",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArg(completePaneTypes(a)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !a.InTmux() {
 				return fmt.Errorf("must be run inside a tmux session")
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// completionFunc is the signature cobra uses for dynamic arg and flag values.
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completionProfile applies --profile, since completion requests skip the
+// root's PersistentPreRun.
+func completionProfile(cmd *cobra.Command, a *app.App) {
+	if profile, err := cmd.Flags().GetString("profile"); err == nil && profile != "" {
+		a.SetProfile(profile)
+	}
+}
+
+// completeTemplates offers template names with their descriptions.
+func completeTemplates(a *app.App) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completionProfile(cmd, a)
+		templates, err := a.ListTemplates()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, t := range templates {
+			if !strings.HasPrefix(t.Name, toComplete) {
+				continue
+			}
+			out = append(out, withDescription(t.Name, t.Description))
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeSessions offers the names of running tmux sessions.
+func completeSessions(a *app.App) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completionProfile(cmd, a)
+		snapshot, err := a.Snapshot()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, s := range snapshot.Sessions {
+			if strings.HasPrefix(s.Name, toComplete) {
+				out = append(out, withDescription(s.Name, s.Path))
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completePaneTypes offers the pane types from the provider registry.
+func completePaneTypes(a *app.App) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var out []string
+		for _, t := range a.PaneTypes() {
+			if strings.HasPrefix(string(t), toComplete) {
+				out = append(out, string(t))
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completePanes offers <session>/<title> for every pane, plus bare titles
+// for panes in the current session, matching what ResolvePane accepts.
+func completePanes(a *app.App) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completionProfile(cmd, a)
+		snapshot, err := a.Snapshot()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, s := range snapshot.Sessions {
+			for _, p := range s.Panes {
+				desc := string(p.Type)
+				if s.Name == snapshot.CurrentSession && strings.HasPrefix(p.Title, toComplete) {
+					out = append(out, withDescription(p.Title, desc))
+				}
+				if ref := s.Name + "/" + p.Title; strings.HasPrefix(ref, toComplete) {
+					out = append(out, withDescription(ref, desc))
+				}
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeCurrentTitles offers the pane titles of the current session.
+func completeCurrentTitles(a *app.App) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completionProfile(cmd, a)
+		snapshot, err := a.Snapshot()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, s := range snapshot.Sessions {
+			if s.Name != snapshot.CurrentSession {
+				continue
+			}
+			for _, p := range s.Panes {
+				if strings.HasPrefix(p.Title, toComplete) {
+					out = append(out, withDescription(p.Title, string(p.Type)))
+				}
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// firstArg limits a completion to the first positional argument.
+func firstArg(f completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return f(cmd, args, toComplete)
+	}
+}
+
+// withDescription formats a completion with cobra's tab-separated help text.
+func withDescription(value, desc string) string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return value
+	}
+	return value + "\t" + desc
+}
@@ -7,12 +7,17 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
 	"github.com/minghinmatthewlam/agentpane/internal/tui/dashboard"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
 	"github.com/spf13/cobra"
 )
 
 func NewDashboardCmd(a *app.App) *cobra.Command {
-	var tmuxWindow bool
+	var (
+		tmuxWindow bool
+		popup      bool
+	)
 	cmd := &cobra.Command{
 		Use:   "dashboard",
 		Short: "Open the interactive dashboard",
@@ -20,55 +25,32 @@ func NewDashboardCmd(a *app.App) *cobra.Command {
 			if tmuxWindow {
 				if os.Getenv("TMUX") == "" {
 					fmt.Fprintln(os.Stderr, "Not in tmux, opening dashboard directly")
-					return runDashboard(a)
+					return runDashboard(a, false)
 				}
 				return a.EnsureDashboardWindow()
 			}
-			return runDashboard(a)
+			return runDashboard(a, popup)
 		},
 	}
 	cmd.Flags().BoolVar(&tmuxWindow, "tmux-window", false, "Open dashboard in a tmux window (recommended over popup)")
+	cmd.Flags().BoolVar(&popup, "popup", false, "Close the dashboard after switching sessions (set by the popup command)")
+	_ = cmd.Flags().MarkHidden("popup")
 	return cmd
 }
 
-func runDashboard(a *app.App) error {
-	model := dashboard.NewModel(a)
-	finalModel, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+func runDashboard(a *app.App, popup bool) error {
+	ui, err := a.UIConfig()
 	if err != nil {
 		return err
 	}
-
-	m, ok := finalModel.(dashboard.Model)
-	if !ok {
-		return nil
-	}
-
-	// Check if user requested to attach to a session
-	if sessionName := m.AttachSession(); sessionName != "" {
-		return a.Attach(sessionName)
-	}
-
-	// Check if user requested to open a new session
-	if path := m.OpenSessionPath(); path != "" {
-		result, err := a.Up(app.UpOptions{Cwd: path})
-		if err != nil {
-			return fmt.Errorf("failed to open session: %w", err)
-		}
-		fmt.Printf("Session '%s' %s\n", result.SessionName, result.Action)
-		return nil
-	}
-
-	// Check if user requested to add a pane
-	if paneType := m.AddPaneType(); paneType != "" {
-		result, err := a.Add(app.AddOptions{Type: paneType})
-		if err != nil {
-			return fmt.Errorf("failed to add pane: %w", err)
-		}
-		if result.FellBackToShell {
-			fmt.Printf("Warning: %s not found in PATH, created shell pane instead\n", paneType)
-		}
-		fmt.Printf("Created pane '%s'\n", result.Title)
+	common.ApplyTheme(ui)
+	km, err := keys.New(ui.Keys)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	model := dashboard.NewModel(a, km)
+	model.SetPopup(popup)
+	_, err = tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	return err
 }
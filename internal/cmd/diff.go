@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/diffview"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+	"github.com/spf13/cobra"
+)
+
+func NewDiffCmd(a *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "diff [pane]",
+		Short:             "Review, stage, discard and commit a pane's git changes",
+		Long:              "Shows the uncommitted changes in the directory a pane is working in (or the current directory when no pane is given). <pane> may be a pane ID (%3), <session>/<title> or a title in the current session.",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: firstArg(completePanes(a)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var dir, title string
+			if len(args) == 0 {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				dir, title = cwd, filepath.Base(cwd)
+			} else {
+				ref, err := a.ResolvePane(args[0])
+				if err != nil {
+					return err
+				}
+				if dir, err = a.PaneDir(ref); err != nil {
+					return err
+				}
+				title = ref.Session + "/" + ref.Title
+			}
+
+			ui, err := a.UIConfig()
+			if err != nil {
+				return err
+			}
+			common.ApplyTheme(ui)
+			km, err := keys.New(ui.Keys)
+			if err != nil {
+				return err
+			}
+
+			model := diffview.New(a, km, dir, title, 0, 0).Standalone()
+			_, err = tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+			return err
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewDownCmd(a *app.App) *cobra.Command {
+	var (
+		session     string
+		all         bool
+		timeout     time.Duration
+		transcripts bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Stop the agents in a session and kill it",
+		Long: "Asks each agent to exit with its quit sequence, waits up to --timeout, then kills the session, runs the hooks.down commands from the config and removes the session from state.\n" +
+			"Without --session or --all, tears down the session for the current directory.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+
+			result, err := a.Down(app.DownOptions{
+				Cwd:         cwd,
+				Session:     session,
+				All:         all,
+				Timeout:     timeout,
+				Transcripts: transcripts,
+			})
+			for _, s := range result.Sessions {
+				if len(s.Forced) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: %s: %s did not exit in time and was killed\n", s.Name, strings.Join(s.Forced, ", "))
+				}
+				for _, w := range s.Warnings {
+					fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", s.Name, w)
+				}
+				if s.TranscriptsDir != "" {
+					fmt.Printf("Saved transcripts to %s\n", s.TranscriptsDir)
+				}
+				fmt.Printf("Stopped session %s\n", s.Name)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Session to tear down")
+	cmd.Flags().BoolVar(&all, "all", false, "Tear down every session")
+	cmd.Flags().DurationVar(&timeout, "timeout", app.DefaultDownTimeout, "How long agents get to exit before the session is killed")
+	cmd.Flags().BoolVar(&transcripts, "transcripts", false, "Archive each pane's scrollback before the agents quit")
+	cmd.MarkFlagsMutuallyExclusive("session", "all")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
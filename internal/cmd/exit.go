@@ -0,0 +1,11 @@
+package cmd
+
+// ExitError makes main exit with Code once cobra has printed Err.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
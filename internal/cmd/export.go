@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/bundle"
+	"github.com/spf13/cobra"
+)
+
+func NewExportCmd(a *app.App) *cobra.Command {
+	var (
+		output      string
+		transcripts bool
+	)
+
+	cmd := &cobra.Command{
+		Use:               "export <session>",
+		Short:             "Export a session as a portable bundle file",
+		Long:              "Writes the session's layout, pane types, titles and commands, config env and git ref to a single YAML file that `agentpane import` recreates. Secrets in commands, env and transcripts are masked.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArg(completeSessions(a)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := a.ExportSession(app.ExportOptions{Session: args[0], Transcripts: transcripts})
+			if err != nil {
+				return err
+			}
+			data, err := bundle.Marshal(b)
+			if err != nil {
+				return err
+			}
+
+			if output == "-" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			if output == "" {
+				output = args[0] + ".agentpane-bundle.yml"
+			}
+			if err := os.WriteFile(output, data, 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("Exported %s (%d panes) to %s\n", args[0], len(b.Template.Panes), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file, - for stdout (default <session>.agentpane-bundle.yml)")
+	cmd.Flags().BoolVar(&transcripts, "transcripts", false, "Include each pane's scrollback")
+	return cmd
+}
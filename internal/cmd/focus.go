@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewFocusCmd(a *app.App) *cobra.Command {
+	var zoom bool
+
+	cmd := &cobra.Command{
+		Use:               "focus <session>/<pane-title>",
+		Short:             "Attach to a session with a specific pane selected",
+		Long:              "Switches to the pane's session and selects the pane. <pane> may also be a pane ID (%3) or a title in the current session.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArg(completePanes(a)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := a.ResolvePane(args[0])
+			if err != nil {
+				return err
+			}
+			return a.Focus(app.FocusOptions{
+				Session: ref.Session,
+				PaneID:  ref.PaneID,
+				Zoom:    zoom,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&zoom, "zoom", "z", false, "Zoom the pane")
+	return cmd
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
 	"github.com/spf13/cobra"
 )
 
-func NewHelpCmd() *cobra.Command {
+func NewHelpCmd(a *app.App) *cobra.Command {
 	return &cobra.Command{
 		Use:   "help",
 		Short: "Show help and recommended tmux configuration",
@@ -17,6 +20,9 @@ COMMANDS:
   up              Create or attach to session for current repo
   add <type>      Add pane (codex, claude, shell)
   rename [name]   Rename current pane
+  restart <pane>  Relaunch a pane's agent in place
+  focus <pane>    Jump to a pane (session/title)
+  supervise       Auto-restart exited agents per restart policy
   dashboard       Open navigation TUI
   popup           Open dashboard as tmux popup
   templates       Browse and apply templates
@@ -31,20 +37,24 @@ Then press Prefix+g from any pane to open the dashboard.
 Reload config with: tmux source-file ~/.tmux.conf
 
 DASHBOARD KEYS:
-  ←/→, h/l    Switch between sessions (repos)
-  ↑/↓, j/k    Navigate
-  Tab         Switch panels
-  /           Filter sessions
-  Enter       Attach to session
-  c           Add Claude pane
-  x           Add Codex pane
-  s           Add Shell pane
-  a           Add pane (dialog)
-  r           Rename pane
-  d           Close pane
-  t           Switch tabs (Sessions/Templates)
-  q           Quit
 `)
+			fmt.Print(dashboardKeys(a).HelpText())
 		},
 	}
 }
+
+// dashboardKeys returns the configured bindings, falling back to the
+// defaults when the config can't be read.
+func dashboardKeys(a *app.App) keys.Map {
+	ui, err := a.UIConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; showing default keys\n", err)
+		return keys.Default()
+	}
+	km, err := keys.New(ui.Keys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; showing default keys\n", err)
+		return keys.Default()
+	}
+	return km
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/bundle"
+	"github.com/spf13/cobra"
+)
+
+func NewImportCmd(a *app.App) *cobra.Command {
+	var (
+		path   string
+		name   string
+		detach bool
+		trust  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Recreate a session from an exported bundle",
+		Long: "Creates a session from a bundle written by `agentpane export`. The repo directory defaults to the exported path when it exists here; otherwise pass --path or enter it when asked.\n" +
+			"Custom pane commands and env from the bundle are shown before anything runs; pass --trust to skip the question.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := bundle.Load(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			result, err := a.ImportBundle(app.ImportOptions{
+				Bundle: b,
+				Path:   path,
+				Name:   name,
+				Detach: detach,
+				Trust:  trust,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, w := range result.Warnings {
+				fmt.Fprintln(os.Stderr, "Warning:", w)
+			}
+			if result.TranscriptsDir != "" {
+				fmt.Printf("Transcripts saved to %s\n", result.TranscriptsDir)
+			}
+			if result.Action == app.ActionDetached {
+				fmt.Printf("Session %s ready in %s\n", result.SessionName, result.Path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Local repo directory to create the session in")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Session name (defaults to the exported name)")
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Create but don't attach")
+	cmd.Flags().BoolVar(&trust, "trust", false, "Run the bundle's commands and env without asking")
+	return cmd
+}
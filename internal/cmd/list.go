@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewListCmd(a *app.App) *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List sessions and panes with their tags and notes",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			sessions, err := a.List()
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(sessions)
+			}
+			if len(sessions) == 0 {
+				fmt.Println("No sessions")
+				return nil
+			}
+			for _, s := range sessions {
+				fmt.Println(withMetadata(fmt.Sprintf("%s (%s)", s.Name, s.Path), s.Tags, s.Note))
+				for _, p := range s.Panes {
+					fmt.Println(withMetadata(fmt.Sprintf("  %-4s %s [%s] %s", p.ID, p.Title, p.Type, p.Status), p.Tags, p.Note))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print sessions as JSON")
+	return cmd
+}
+
+// withMetadata appends tags as #tag and the note to a line.
+func withMetadata(line string, tags []string, note string) string {
+	for _, tag := range tags {
+		line += " #" + tag
+	}
+	if note != "" {
+		line += " · " + strings.ReplaceAll(note, "\n", " ")
+	}
+	return line
+}
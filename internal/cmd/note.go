@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewNoteCmd(a *app.App) *cobra.Command {
+	var (
+		session  string
+		pane     string
+		clearAll bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "note [text...]",
+		Short: "Set or show the note on a pane or session",
+		Long: "Sets a free-form note, such as the task an agent is working on, on the pane given by --pane,\n" +
+			"the session given by --session, or the current pane. Without text, prints the current note.",
+		Example: "  agentpane note \"migrating auth to OAuth\"\n" +
+			"  agentpane note --pane app/codex-1 --clear",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.TrimSpace(strings.Join(args, " "))
+			if clearAll && text != "" {
+				return fmt.Errorf("--clear does not take a note")
+			}
+			cmd.SilenceUsage = true
+
+			target, err := a.ResolveMetaTarget(session, pane)
+			if err != nil {
+				return err
+			}
+			if text == "" && !clearAll {
+				if note := a.Metadata(target).Note; note != "" {
+					fmt.Println(note)
+				}
+				return nil
+			}
+			if err := a.SetNote(target, text); err != nil {
+				return err
+			}
+			if clearAll {
+				fmt.Printf("Cleared note on %s\n", target)
+			} else {
+				fmt.Printf("Set note on %s\n", target)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Set the note of this session (or look up --pane in it)")
+	cmd.Flags().StringVar(&pane, "pane", "", "Set the note of this pane (ID, session/title or title)")
+	cmd.Flags().BoolVar(&clearAll, "clear", false, "Remove the note")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	_ = cmd.RegisterFlagCompletionFunc("pane", completePanes(a))
+	return cmd
+}
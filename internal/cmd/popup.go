@@ -16,17 +16,17 @@ func NewPopupCmd(a *app.App) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if os.Getenv("TMUX") == "" {
 				fmt.Fprintln(os.Stderr, "Not in tmux, opening dashboard directly")
-				return runDashboard(a)
+				return runDashboard(a, false)
 			}
 
 			supported, err := a.SupportsPopup()
 			if err != nil {
 				fmt.Fprintln(os.Stderr, "Failed to detect tmux popup support, opening dashboard directly:", err)
-				return runDashboard(a)
+				return runDashboard(a, false)
 			}
 
 			if supported {
-				if err := a.OpenPopup("agentpane", "dashboard"); err == nil {
+				if err := a.OpenPopup("agentpane", "dashboard", "--popup"); err == nil {
 					return nil
 				}
 				// Popup failed; fall back to a tmux window.
@@ -35,7 +35,7 @@ func NewPopupCmd(a *app.App) *cobra.Command {
 
 			if err := a.OpenDashboardWindow(); err != nil {
 				fmt.Fprintln(os.Stderr, "Failed to open dashboard window, opening dashboard directly:", err)
-				return runDashboard(a)
+				return runDashboard(a, false)
 			}
 			return nil
 		},
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+	"github.com/spf13/cobra"
+)
+
+func NewQueueCmd(a *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Queue prompts and hand them to agent panes as they become idle",
+		Long: "Keeps a per-session queue of prompts in state. `queue dispatch` hands the next prompt to whichever\n" +
+			"agent pane in the session goes idle, and records which pane took it and when it finished.",
+	}
+	cmd.AddCommand(newQueueAddCmd(a))
+	cmd.AddCommand(newQueueStatusCmd(a))
+	cmd.AddCommand(newQueueDispatchCmd(a))
+	cmd.AddCommand(newQueueClearCmd(a))
+	return cmd
+}
+
+func newQueueAddCmd(a *app.App) *cobra.Command {
+	var (
+		session string
+		files   []string
+		dir     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add [prompt]",
+		Short: "Add prompts to a session's queue",
+		Long: "Adds a prompt, the contents of --file (repeatable) or every file in --dir, in name order.\n" +
+			"The session defaults to the current one, or the one started in the working directory.",
+		Example: "  agentpane queue add \"Fix the flaky login test\"\n" +
+			"  agentpane queue add --dir tasks/ --session app",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var prompts []app.QueuedPrompt
+			if len(args) == 1 {
+				prompts = append(prompts, app.QueuedPrompt{Prompt: args[0]})
+			}
+			if dir != "" {
+				dirFiles, err := taskFiles(dir)
+				if err != nil {
+					return err
+				}
+				files = append(files, dirFiles...)
+			}
+			for _, f := range files {
+				data, err := os.ReadFile(f)
+				if err != nil {
+					return err
+				}
+				prompts = append(prompts, app.QueuedPrompt{Prompt: string(data), Source: f})
+			}
+			if len(prompts) == 0 {
+				return fmt.Errorf("nothing to add (pass a prompt, --file or --dir)")
+			}
+			for _, p := range prompts {
+				if strings.TrimSpace(p.Prompt) == "" {
+					if p.Source != "" {
+						return fmt.Errorf("%s is empty", p.Source)
+					}
+					return fmt.Errorf("prompt must not be empty")
+				}
+			}
+			cmd.SilenceUsage = true
+
+			name, err := a.QueueSession(session)
+			if err != nil {
+				return err
+			}
+			added, err := a.QueueAdd(name, prompts)
+			if err != nil {
+				return err
+			}
+			for _, t := range added {
+				fmt.Printf("Queued #%d in %s: %s\n", t.ID, t.Session, taskSummary(t))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Session to queue the prompts for")
+	cmd.Flags().StringArrayVarP(&files, "file", "f", nil, "Read a prompt from a file (repeatable)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Queue every file in a directory, in name order")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	_ = cmd.MarkFlagDirname("dir")
+	return cmd
+}
+
+// taskFiles lists the regular files in dir by name, skipping hidden ones.
+func taskFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no task files in %s", dir)
+	}
+	return files, nil
+}
+
+func newQueueStatusCmd(a *app.App) *cobra.Command {
+	var session string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show queued, running and finished tasks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks := a.QueueTasks(session)
+			if len(tasks) == 0 {
+				fmt.Println("No tasks queued")
+				return nil
+			}
+			fmt.Printf("%-4s %-12s %-8s %-16s %-8s %-8s %s\n", "ID", "SESSION", "STATUS", "PANE", "STARTED", "FINISHED", "PROMPT")
+			for _, t := range tasks {
+				fmt.Printf("%-4d %-12s %-8s %-16s %-8s %-8s %s\n",
+					t.ID, t.Session, t.Status, orDash(t.Pane), taskTime(t.StartedAt), taskTime(t.FinishedAt), taskSummary(t))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Only show tasks of this session")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
+
+func newQueueDispatchCmd(a *app.App) *cobra.Command {
+	var (
+		session string
+		idleFor time.Duration
+		follow  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dispatch",
+		Short: "Hand queued tasks to agent panes as they become idle",
+		Long: "Sends the next queued prompt to each agent pane whose output has been unchanged for --idle-for.\n" +
+			"A task is done when its pane goes idle again, and failed if the agent exits. Returns once the\n" +
+			"queue is drained, or keeps watching for new tasks with --follow.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			name, err := a.QueueSession(session)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return a.Dispatch(ctx, app.DispatchOptions{
+				Session: name,
+				IdleFor: idleFor,
+				Follow:  follow,
+			}, func(ev app.DispatchEvent) {
+				ts := time.Now().Format("15:04:05")
+				switch ev.Action {
+				case "started":
+					fmt.Printf("%s #%d started on %s: %s\n", ts, ev.Task.ID, ev.Task.Pane, taskSummary(ev.Task))
+				case "done":
+					fmt.Printf("%s #%d done on %s\n", ts, ev.Task.ID, ev.Task.Pane)
+				case "failed":
+					fmt.Fprintf(os.Stderr, "%s #%d failed on %s: %v\n", ts, ev.Task.ID, ev.Task.Pane, ev.Err)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Session whose queue to dispatch")
+	cmd.Flags().DurationVar(&idleFor, "idle-for", app.DefaultWaitIdle, "How long output must be unchanged for a pane to count as idle")
+	cmd.Flags().BoolVar(&follow, "follow", false, "Keep running and dispatch tasks added later")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
+
+func newQueueClearCmd(a *app.App) *cobra.Command {
+	var session string
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove finished tasks from the queue",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			removed, err := a.ClearQueue(session)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Removed %d finished task(s)\n", removed)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Only clear tasks of this session")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
+
+// taskSummary is the first line of a task's prompt, or its file if it has one.
+func taskSummary(t state.Task) string {
+	if t.Source != "" {
+		return t.Source
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(t.Prompt), "\n")
+	return ansi.Truncate(line, 60, "…")
+}
+
+func taskTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("15:04:05")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -9,9 +9,10 @@ import (
 
 func NewRenameCmd(a *app.App) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rename [name]",
-		Short: "Rename the current pane",
-		Args:  cobra.MaximumNArgs(1),
+		Use:               "rename [name]",
+		Short:             "Rename the current pane",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: firstArg(completeCurrentTitles(a)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !a.InTmux() {
 				return fmt.Errorf("must be run inside a tmux session")
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewRestartCmd(a *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart <pane>",
+		Short: "Relaunch a pane's agent in place",
+		Long: "Respawns the pane and relaunches its recorded provider command, keeping the pane ID and title.\n" +
+			"<pane> is a pane ID (%3), <session>/<title>, or a title in the current session.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArg(completePanes(a)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := a.ResolvePane(args[0])
+			if err != nil {
+				return err
+			}
+			result, err := a.RestartPane(app.RestartOptions{
+				Session: ref.Session,
+				PaneID:  ref.PaneID,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restarted pane '%s' (restart #%d)\n", result.Title, result.Restarts)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -6,23 +6,43 @@ import (
 )
 
 func NewRootCmd(a *app.App) *cobra.Command {
+	var profile string
+
 	root := &cobra.Command{
 		Use:   "agentpane",
 		Short: "tmux-based AI coding agent manager",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			a.SetProfile(profile)
+		},
 		// Default to dashboard when no subcommand given
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDashboard(a)
+			return runDashboard(a, false)
 		},
 	}
 
+	root.PersistentFlags().StringVar(&profile, "profile", "", "Config profile to use (overrides $AGENTPANE_PROFILE)")
+
 	root.AddCommand(NewUpCmd(a))
+	root.AddCommand(NewDownCmd(a))
+	root.AddCommand(NewRunCmd(a))
+	root.AddCommand(NewWaitCmd(a))
+	root.AddCommand(NewQueueCmd(a))
 	root.AddCommand(NewInitCmd(a))
 	root.AddCommand(NewAddCmd(a))
 	root.AddCommand(NewRenameCmd(a))
+	root.AddCommand(NewTagCmd(a))
+	root.AddCommand(NewNoteCmd(a))
+	root.AddCommand(NewListCmd(a))
+	root.AddCommand(NewRestartCmd(a))
+	root.AddCommand(NewFocusCmd(a))
+	root.AddCommand(NewDiffCmd(a))
+	root.AddCommand(NewSuperviseCmd(a))
 	root.AddCommand(NewDashboardCmd(a))
 	root.AddCommand(NewPopupCmd(a))
 	root.AddCommand(NewTemplatesCmd(a))
-	root.AddCommand(NewHelpCmd())
+	root.AddCommand(NewHelpCmd(a))
 	root.AddCommand(NewSearchCmd(a))
+	root.AddCommand(NewExportCmd(a))
+	root.AddCommand(NewImportCmd(a))
 	return root
 }
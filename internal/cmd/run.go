@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// exitTimeout is the exit code when a run times out, as with timeout(1).
+const exitTimeout = 124
+
+func NewRunCmd(a *app.App) *cobra.Command {
+	var (
+		paneType   string
+		prompt     string
+		promptFile string
+		session    string
+		title      string
+		output     string
+		timeout    time.Duration
+		idle       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run an agent on a prompt and save its transcript and diff",
+		Long: "Starts an agent in a new pane, delivers the prompt and waits until the agent is idle (its output has not changed for --idle) or exits, up to --timeout. " +
+			"The transcript, the git diff since the start and result.json are saved to --output. The pane stays open so the run can be inspected in tmux.\n\n" +
+			"Exits 0 when the agent went idle or exited cleanly, with the agent's status when it failed, and 124 on timeout. " +
+			"A shell run executes the prompt as a command and waits for it to finish.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := domain.ParsePaneType(paneType)
+			if err != nil {
+				return err
+			}
+			if promptFile != "" {
+				var data []byte
+				if promptFile == "-" {
+					data, err = io.ReadAll(os.Stdin)
+				} else {
+					data, err = os.ReadFile(promptFile)
+				}
+				if err != nil {
+					return err
+				}
+				prompt = string(data)
+			}
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			result, err := a.Run(app.RunOptions{
+				Type:      t,
+				Prompt:    prompt,
+				Session:   session,
+				Title:     title,
+				Cwd:       cwd,
+				OutputDir: output,
+				Timeout:   timeout,
+				Idle:      idle,
+			})
+			if err != nil {
+				return err
+			}
+
+			elapsed := result.Finished.Sub(result.Started).Round(time.Second)
+			fmt.Printf("%s/%s: %s after %s, %d files changed\n", result.Session, result.Title, result.Outcome, elapsed, len(result.Files))
+			fmt.Printf("Results in %s\n", result.OutputDir)
+			if result.Succeeded() {
+				return nil
+			}
+			switch {
+			case result.Outcome == app.RunTimeout:
+				return &ExitError{Code: exitTimeout, Err: fmt.Errorf("timed out after %s", timeout)}
+			case result.ExitStatus > 0:
+				return &ExitError{Code: result.ExitStatus, Err: fmt.Errorf("%s exited with status %d", paneType, result.ExitStatus)}
+			}
+			return &ExitError{Code: 1, Err: fmt.Errorf("%s exited without reporting a status", paneType)}
+		},
+	}
+
+	cmd.Flags().StringVar(&paneType, "type", "codex", "Pane type: codex, claude or shell")
+	cmd.Flags().StringVarP(&prompt, "prompt", "p", "", "Prompt to give the agent")
+	cmd.Flags().StringVarP(&promptFile, "prompt-file", "f", "", "Read the prompt from a file, - for stdin")
+	cmd.Flags().StringVar(&session, "session", "", "Session to run in, created if missing (defaults to the repo's session)")
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Pane title")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Results directory (default ~/.local/share/agentpane/runs/<session>-<title>-<time>)")
+	cmd.Flags().DurationVar(&timeout, "timeout", app.DefaultRunTimeout, "Give up after this long")
+	cmd.Flags().DurationVar(&idle, "idle", app.DefaultRunIdle, "Treat the agent as done once its output is unchanged this long")
+	cmd.MarkFlagsMutuallyExclusive("prompt", "prompt-file")
+	cmd.MarkFlagsOneRequired("prompt", "prompt-file")
+	_ = cmd.RegisterFlagCompletionFunc("type", completePaneTypes(a))
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
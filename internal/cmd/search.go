@@ -14,8 +14,20 @@ func NewSearchCmd(a *app.App) *cobra.Command {
 		Short: "Search across sessions and panes",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			model := search.NewModel(a)
-			_, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
-			return err
+			final, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+			if err != nil {
+				return err
+			}
+
+			m, ok := final.(search.Model)
+			if !ok || m.Selected() == nil {
+				return nil
+			}
+			r := m.Selected()
+			if r.PaneID == "" {
+				return a.Attach(r.Session)
+			}
+			return a.Focus(app.FocusOptions{Session: r.Session, PaneID: r.PaneID})
 		},
 	}
 	return cmd
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewSuperviseCmd(a *app.App) *cobra.Command {
+	var (
+		session  string
+		interval time.Duration
+		once     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "supervise",
+		Short: "Restart exited agents according to their restart policy",
+		Long: "Watches agent panes and relaunches exited ones whose restart policy is on-failure or always,\n" +
+			"with exponential backoff and a max retry count. Each restart is logged to state.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return a.Supervise(ctx, app.SuperviseOptions{
+				Session:  session,
+				Interval: interval,
+				Once:     once,
+			}, func(ev app.SuperviseEvent) {
+				ts := time.Now().Format("15:04:05")
+				switch ev.Action {
+				case "restarted":
+					fmt.Printf("%s restarted %s/%s (%s)\n", ts, ev.Session, ev.Title, ev.Reason)
+				case "gave-up":
+					fmt.Printf("%s giving up on %s/%s: %s\n", ts, ev.Session, ev.Title, ev.Reason)
+				case "failed":
+					fmt.Fprintf(os.Stderr, "%s failed to restart %s/%s: %v\n", ts, ev.Session, ev.Title, ev.Err)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Only supervise this session")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "How often to check panes")
+	cmd.Flags().BoolVar(&once, "once", false, "Check once and exit")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+	"github.com/spf13/cobra"
+)
+
+func NewTagCmd(a *app.App) *cobra.Command {
+	var (
+		session  string
+		pane     string
+		remove   bool
+		clearAll bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tag [tag...]",
+		Short: "Add, remove or show tags on a pane or session",
+		Long: "Tags the pane given by --pane, the session given by --session, or the current pane.\n" +
+			"Without tags, prints the current ones. Tags are shown in the dashboard, matched by its filter and search, and included in `list --json`.",
+		Example: "  agentpane tag backend auth\n" +
+			"  agentpane tag --pane app/claude-2 -d auth\n" +
+			"  agentpane tag --session app --clear release",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags := state.ParseTags(strings.Join(args, ","))
+			if remove && len(tags) == 0 {
+				return fmt.Errorf("--remove needs the tags to remove")
+			}
+			cmd.SilenceUsage = true
+
+			target, err := a.ResolveMetaTarget(session, pane)
+			if err != nil {
+				return err
+			}
+			if len(tags) == 0 && !clearAll {
+				printTags(target, a.Metadata(target).Tags)
+				return nil
+			}
+
+			edit := app.TagEdit{Replace: clearAll}
+			if remove {
+				edit.Remove = tags
+			} else {
+				edit.Add = tags
+			}
+			result, err := a.EditTags(target, edit)
+			if err != nil {
+				return err
+			}
+			printTags(target, result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Tag this session (or look up --pane in it)")
+	cmd.Flags().StringVar(&pane, "pane", "", "Tag this pane (ID, session/title or title)")
+	cmd.Flags().BoolVarP(&remove, "remove", "d", false, "Remove the given tags instead of adding them")
+	cmd.Flags().BoolVar(&clearAll, "clear", false, "Remove all tags, replacing them with any given")
+	cmd.MarkFlagsMutuallyExclusive("remove", "clear")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	_ = cmd.RegisterFlagCompletionFunc("pane", completePanes(a))
+	return cmd
+}
+
+func printTags(target app.MetaTarget, tags []string) {
+	if len(tags) == 0 {
+		fmt.Printf("%s: no tags\n", target)
+		return
+	}
+	fmt.Printf("%s:%s\n", target, withMetadata("", tags, ""))
+}
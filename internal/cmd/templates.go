@@ -10,9 +10,12 @@ import (
 
 func NewTemplatesCmd(a *app.App) *cobra.Command {
 	var (
-		applyName string
-		force     bool
-		session   string
+		applyName    string
+		force        bool
+		session      string
+		reconcile    bool
+		removeExtras bool
+		dryRun       bool
 	)
 
 	cmd := &cobra.Command{
@@ -20,12 +23,23 @@ func NewTemplatesCmd(a *app.App) *cobra.Command {
 		Short: "List or apply templates",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if applyName != "" {
-				_, err := a.ApplyTemplate(app.ApplyTemplateOptions{
-					Session:  session,
-					Template: applyName,
-					Force:    force,
+				// --remove-extras and --dry-run only make sense when reconciling
+				reconcile = reconcile || removeExtras || dryRun
+				result, err := a.ApplyTemplate(app.ApplyTemplateOptions{
+					Session:      session,
+					Template:     applyName,
+					Force:        force,
+					Reconcile:    reconcile,
+					RemoveExtras: removeExtras,
+					DryRun:       dryRun,
 				})
-				return err
+				if err != nil {
+					return err
+				}
+				if reconcile {
+					printPlan(result, dryRun)
+				}
+				return nil
 			}
 
 			templates, err := a.ListTemplates()
@@ -51,9 +65,75 @@ func NewTemplatesCmd(a *app.App) *cobra.Command {
 	cmd.Flags().StringVar(&applyName, "apply", "", "Apply a template to a session")
 	cmd.Flags().BoolVar(&force, "force", false, "Apply template without confirmation")
 	cmd.Flags().StringVar(&session, "session", "", "Session name to apply the template to")
+	cmd.Flags().BoolVar(&reconcile, "reconcile", false, "Keep matching panes running and only add missing ones")
+	cmd.Flags().BoolVar(&removeExtras, "remove-extras", false, "When reconciling, close panes not in the template")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the reconcile plan without changing anything")
+	_ = cmd.RegisterFlagCompletionFunc("apply", completeTemplates(a))
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	cmd.AddCommand(newTemplatesSaveCmd(a))
+	return cmd
+}
+
+func newTemplatesSaveCmd(a *app.App) *cobra.Command {
+	var (
+		global  bool
+		repo    bool
+		session string
+		force   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a running session's panes and layout as a template",
+		Long:  "Captures pane types, titles, custom commands and the tmux layout of a session (the current one by default) as a named template, written to the global config or, with --repo, to the repo's .agentpane.yml.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := a.SaveCurrentAsTemplate(app.SaveCurrentOptions{
+				Name:    args[0],
+				Session: session,
+				Repo:    repo,
+				Force:   force,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Saved template %s (%d panes) to %s\n", result.Name, len(result.Template.Panes), result.Path)
+			fmt.Printf("Use it with: agentpane up --template %s\n", result.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "Write to the global config (default)")
+	cmd.Flags().BoolVar(&repo, "repo", false, "Write to the repo's .agentpane.yml")
+	cmd.Flags().StringVar(&session, "session", "", "Session to save (defaults to the current one)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing template")
+	cmd.MarkFlagsMutuallyExclusive("global", "repo")
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
 	return cmd
 }
 
+func printPlan(result app.ApplyTemplateResult, dryRun bool) {
+	if dryRun {
+		fmt.Printf("Plan for applying %s to %s:\n", result.Template, result.Session)
+	} else {
+		fmt.Printf("Applied %s to %s:\n", result.Template, result.Session)
+	}
+	extras := 0
+	for _, step := range result.Plan {
+		id := step.PaneID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("  %-7s %-4s %-7s %s\n", step.Action, id, step.Type, step.Title)
+		if step.Action == app.PlanExtra {
+			extras++
+		}
+	}
+	if extras > 0 {
+		fmt.Printf("\n%d pane(s) not in the template were kept; use --remove-extras to close them.\n", extras)
+	}
+}
+
 func templateSessionHint(session string) string {
 	if strings.TrimSpace(session) != "" {
 		return " --session " + session
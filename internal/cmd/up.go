@@ -13,6 +13,7 @@ func NewUpCmd(a *app.App) *cobra.Command {
 		sessionName string
 		template    string
 		detach      bool
+		workspace   string
 	)
 
 	cmd := &cobra.Command{
@@ -29,6 +30,7 @@ func NewUpCmd(a *app.App) *cobra.Command {
 				ExplicitName: sessionName,
 				Template:     template,
 				Detach:       detach,
+				Workspace:    workspace,
 			})
 			if err != nil {
 				return err
@@ -60,6 +62,8 @@ func NewUpCmd(a *app.App) *cobra.Command {
 	cmd.Flags().StringVarP(&sessionName, "name", "n", "", "Explicit session name")
 	cmd.Flags().StringVarP(&template, "template", "t", "", "Use specific template")
 	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Create but don't attach")
+	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", "Create a session spanning the repos of a workspace")
+	_ = cmd.RegisterFlagCompletionFunc("template", completeTemplates(a))
 
 	return cmd
 }
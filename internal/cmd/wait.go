@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewWaitCmd(a *app.App) *cobra.Command {
+	var (
+		session  string
+		paneType string
+		status   string
+		match    string
+		anyPane  bool
+		idleFor  time.Duration
+		timeout  time.Duration
+		asJSON   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wait [pane...]",
+		Short: "Block until panes reach a status or show matching output",
+		Long: "Waits until every target pane (or any, with --any) has the given --status, or its visible output matches --match, whichever happens first.\n" +
+			"Panes are IDs (%3), <session>/<title> or titles in the current session; without any, all panes of --session (default: the current session) are targets, optionally limited by --type.\n\n" +
+			"Statuses: active (output changed since the wait started), idle (running but output unchanged for --idle-for) and exited. Exits 124 on timeout.",
+		Example: "  agentpane wait --session ci-foo --type claude --status idle\n" +
+			"  agentpane wait app/shell-1 --match 'All tests passed' --timeout 10m",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := app.WaitOptions{
+				Session: session,
+				Status:  status,
+				Any:     anyPane,
+				IdleFor: idleFor,
+				Timeout: timeout,
+			}
+			if paneType != "" {
+				t, err := domain.ParsePaneType(paneType)
+				if err != nil {
+					return err
+				}
+				opts.Type = t
+			}
+			if match != "" {
+				re, err := regexp.Compile(match)
+				if err != nil {
+					return fmt.Errorf("invalid --match: %w", err)
+				}
+				opts.Match = re
+			}
+			for _, arg := range args {
+				ref, err := a.ResolvePane(arg)
+				if err != nil {
+					return err
+				}
+				opts.Panes = append(opts.Panes, ref)
+			}
+			cmd.SilenceUsage = true
+
+			result, err := a.Wait(opts)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(result); err != nil {
+					return err
+				}
+			} else if !result.TimedOut() {
+				for _, p := range result.Panes {
+					if p.Met {
+						fmt.Printf("%s/%s: %s\n", p.Session, p.Title, describeWaitPane(p, result.Condition))
+					}
+				}
+			}
+			if result.TimedOut() {
+				return &ExitError{Code: exitTimeout, Err: fmt.Errorf("timed out after %s", timeout)}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&session, "session", "", "Wait on the panes of this session")
+	cmd.Flags().StringVar(&paneType, "type", "", "Only wait on panes of this type")
+	cmd.Flags().StringVar(&status, "status", "", "Status to wait for: active, idle or exited")
+	cmd.Flags().StringVar(&match, "match", "", "Regular expression the visible output must match")
+	cmd.Flags().BoolVar(&anyPane, "any", false, "Stop when any pane meets the condition instead of all")
+	cmd.Flags().DurationVar(&idleFor, "idle-for", app.DefaultWaitIdle, "How long output must be unchanged to count as idle")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up after this long (default: wait forever)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the result as JSON")
+	cmd.ValidArgsFunction = completePanes(a)
+	_ = cmd.RegisterFlagCompletionFunc("session", completeSessions(a))
+	_ = cmd.RegisterFlagCompletionFunc("type", completePaneTypes(a))
+	_ = cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+		[]string{app.WaitActive, app.WaitIdle, app.WaitExited}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func describeWaitPane(p app.WaitPane, condition string) string {
+	if condition == app.ConditionMatch {
+		return fmt.Sprintf("matched %q", p.Match)
+	}
+	if p.ExitStatus != nil {
+		return fmt.Sprintf("%s (status %d)", p.Status, *p.ExitStatus)
+	}
+	return p.Status
+}
@@ -6,5 +6,6 @@ func DefaultConfig() *Config {
 		DefaultTemplate: "duo",
 		Providers:       map[string]ProviderConfig{},
 		Templates:       map[string]Template{},
+		Env:             map[string]string{},
 	}
 }
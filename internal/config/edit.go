@@ -0,0 +1,124 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetTemplate writes a named template into the config file at path, creating
+// the file or its templates section when missing. The file is edited as a
+// YAML node tree so comments and key order elsewhere are kept.
+func SetTemplate(path, name string, tmpl Template) error {
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+
+	var value yaml.Node
+	if err := value.Encode(tmpl); err != nil {
+		return err
+	}
+
+	templates := mappingEntry(doc.Content[0], "templates", true)
+	if templates.Kind != yaml.MappingNode {
+		// e.g. "templates:" with no value
+		*templates = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	if existing := mappingEntry(templates, name, false); existing != nil {
+		value.HeadComment = existing.HeadComment
+		value.LineComment = existing.LineComment
+		*existing = value
+	} else {
+		templates.Content = append(templates.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+			&value,
+		)
+	}
+	return writeDocument(path, doc)
+}
+
+// RemoveTemplate deletes a named template from the config file at path. It
+// reports whether the template was there.
+func RemoveTemplate(path, name string) (bool, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return false, err
+	}
+	templates := mappingEntry(doc.Content[0], "templates", false)
+	if templates == nil || templates.Kind != yaml.MappingNode {
+		return false, nil
+	}
+	for i := 0; i+1 < len(templates.Content); i += 2 {
+		if templates.Content[i].Value == name {
+			templates.Content = append(templates.Content[:i], templates.Content[i+2:]...)
+			return true, writeDocument(path, doc)
+		}
+	}
+	return false, nil
+}
+
+// readDocument parses a config file into a document whose root is a
+// mapping. A missing or empty file yields an empty mapping.
+func readDocument(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping at the top level", path)
+	}
+	return &doc, nil
+}
+
+func writeDocument(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// mappingEntry returns the value node for key in a mapping node. When create
+// is set, a missing key is appended with an empty mapping value.
+func mappingEntry(m *yaml.Node, key string, create bool) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	if !create {
+		return nil
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+	return value
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSetTemplatePreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	original := `# my agentpane config
+default_template: duo # the usual
+
+templates:
+  # pair programming
+  pair:
+    panes:
+      - type: claude
+  review:
+    panes:
+      - type: codex
+`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	err := SetTemplate(path, "pair", Template{Panes: []PaneSpec{{Type: "claude"}, {Type: "shell", Title: "server"}}})
+	if err != nil {
+		t.Fatalf("SetTemplate: %v", err)
+	}
+	if err := SetTemplate(path, "solo", Template{Description: "one", Panes: []PaneSpec{{Type: "codex"}}}); err != nil {
+		t.Fatalf("SetTemplate new: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{"# my agentpane config", "# the usual", "# pair programming"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("comment %q lost:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "pair:") > strings.Index(out, "review:") || strings.Index(out, "review:") > strings.Index(out, "solo:") {
+		t.Fatalf("template order not kept:\n%s", out)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := cfg.Templates["pair"].Panes; len(got) != 2 || got[1].Title != "server" {
+		t.Fatalf("unexpected pair panes: %#v", got)
+	}
+	if cfg.Templates["solo"].Description != "one" || cfg.DefaultTemplate != "duo" {
+		t.Fatalf("unexpected config: %#v", cfg)
+	}
+}
+
+func TestSetTemplateCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agentpane", "config.yml")
+	if err := SetTemplate(path, "solo", Template{Panes: []PaneSpec{{Type: "codex"}}}); err != nil {
+		t.Fatalf("SetTemplate: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(data) != "templates:\n  solo:\n    panes:\n      - type: codex\n" {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	original := "templates:\n  a:\n    panes:\n      - type: codex\n  b:\n    panes:\n      - type: shell\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	removed, err := RemoveTemplate(path, "a")
+	if err != nil || !removed {
+		t.Fatalf("RemoveTemplate: removed=%v err=%v", removed, err)
+	}
+	if removed, _ := RemoveTemplate(path, "missing"); removed {
+		t.Fatalf("expected missing template not to be removed")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "templates:\n  b:\n    panes:\n      - type: shell\n" {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpandContext holds the values substituted for {{...}} placeholders.
+type ExpandContext struct {
+	Repo   string
+	Branch string
+	Date   string
+}
+
+var (
+	envVarRe      = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+	placeholderRe = regexp.MustCompile(`\{\{\s*([a-z]+)\s*\}\}`)
+)
+
+// NewExpandContext builds the placeholder values for a repo rooted at dir.
+// The branch is only looked up when withBranch is set, since it spawns git.
+func NewExpandContext(dir string, withBranch bool) ExpandContext {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	ctx := ExpandContext{
+		Repo: filepath.Base(dir),
+		Date: time.Now().Format("2006-01-02"),
+	}
+	if withBranch {
+		ctx.Branch = currentBranch(dir)
+	}
+	return ctx
+}
+
+// Expand substitutes ${VAR}, ${VAR:-default} and {{repo}}, {{branch}},
+// {{date}} placeholders in s. {{index}} is replaced only when index > 0,
+// which is the case for pane titles. Unknown placeholders are left as-is.
+func Expand(s string, ctx ExpandContext, index int) string {
+	if !strings.Contains(s, "${") && !strings.Contains(s, "{{") {
+		return s
+	}
+
+	s = envVarRe.ReplaceAllStringFunc(s, func(m string) string {
+		parts := envVarRe.FindStringSubmatch(m)
+		if v, ok := os.LookupEnv(parts[1]); ok && v != "" {
+			return v
+		}
+		return parts[3]
+	})
+
+	return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		parts := placeholderRe.FindStringSubmatch(m)
+		switch parts[1] {
+		case "repo":
+			return ctx.Repo
+		case "branch":
+			return ctx.Branch
+		case "date":
+			return ctx.Date
+		case "index":
+			if index > 0 {
+				return strconv.Itoa(index)
+			}
+		}
+		return m
+	})
+}
+
+// ExpandPanes expands pane titles and commands, keeping the command as
+// written in RawCommand when expansion changes it.
+func ExpandPanes(panes []PaneSpec, ctx ExpandContext) []PaneSpec {
+	if panes == nil {
+		return nil
+	}
+	out := make([]PaneSpec, len(panes))
+	for i, p := range panes {
+		p.Title = Expand(p.Title, ctx, i+1)
+		if cmd := Expand(p.Command, ctx, 0); cmd != p.Command {
+			p.RawCommand, p.Command = p.Command, cmd
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// expandLoaded applies Expand to the merged templates, provider commands, env
+// and the repo config. Global is left untouched so it reflects the file on
+// disk, and the unexpanded env is kept in RawEnv.
+func expandLoaded(l *Loaded, ctx ExpandContext) {
+	for name, tmpl := range l.Merged.Templates {
+		tmpl.Panes = ExpandPanes(tmpl.Panes, ctx)
+		l.Merged.Templates[name] = tmpl
+	}
+	for name, prov := range l.Merged.Providers {
+		prov.Command = Expand(prov.Command, ctx, 0)
+		l.Merged.Providers[name] = prov
+	}
+	l.RawEnv = l.Merged.Env
+	env := make(map[string]string, len(l.Merged.Env))
+	for k, v := range l.Merged.Env {
+		env[k] = Expand(v, ctx, 0)
+	}
+	l.Merged.Env = env
+	for i, hook := range l.Merged.Hooks.Down {
+		l.Merged.Hooks.Down[i] = Expand(hook, ctx, 0)
+	}
+	if l.Repo != nil {
+		l.Repo.Session = Expand(l.Repo.Session, ctx, 0)
+		l.Repo.Layout.Panes = ExpandPanes(l.Repo.Layout.Panes, ctx)
+	}
+}
+
+// usesBranch reports whether config data contains a {{branch}} placeholder,
+// so the branch is only looked up when needed.
+func usesBranch(data []byte) bool {
+	for _, m := range placeholderRe.FindAllSubmatch(data, -1) {
+		if string(m[1]) == "branch" {
+			return true
+		}
+	}
+	return false
+}
+
+func currentBranch(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("AGENTPANE_TEST_MODEL", "o3")
+	t.Setenv("AGENTPANE_TEST_EMPTY", "")
+	ctx := ExpandContext{Repo: "app", Branch: "feat/x", Date: "2025-01-02"}
+
+	cases := []struct {
+		in    string
+		index int
+		want  string
+	}{
+		{"codex --model ${AGENTPANE_TEST_MODEL}", 0, "codex --model o3"},
+		{"${AGENTPANE_TEST_MISSING:-fallback}", 0, "fallback"},
+		{"${AGENTPANE_TEST_EMPTY:-fallback}", 0, "fallback"},
+		{"${AGENTPANE_TEST_MISSING}", 0, ""},
+		{"claude-{{branch}}", 0, "claude-feat/x"},
+		{"{{ repo }}-{{date}}", 0, "app-2025-01-02"},
+		{"worker-{{index}}", 3, "worker-3"},
+		{"worker-{{index}}", 0, "worker-{{index}}"},
+		{"{{unknown}}", 0, "{{unknown}}"},
+	}
+	for _, c := range cases {
+		if got := Expand(c.in, ctx, c.index); got != c.want {
+			t.Errorf("Expand(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestExpandPanesKeepsRawCommand(t *testing.T) {
+	ctx := ExpandContext{Repo: "app", Branch: "feat-x"}
+	panes := ExpandPanes([]PaneSpec{
+		{Type: "codex", Command: "codex --profile {{repo}}-{{branch}}"},
+		{Type: "shell", Command: "npm run dev"},
+	}, ctx)
+
+	if p := panes[0]; p.Command != "codex --profile app-feat-x" || p.RawCommand != "codex --profile {{repo}}-{{branch}}" {
+		t.Fatalf("unexpected expanded pane %+v", p)
+	}
+	if p := panes[1]; p.Command != "npm run dev" || p.RawCommand != "" {
+		t.Fatalf("unexpected plain pane %+v", p)
+	}
+}
+
+func TestLoadAllExpandsRepoConfig(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv("AGENTPANE_TEST_SUFFIX", "dev")
+
+	repo := filepath.Join(tmp, "myrepo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	repoCfg := []byte("session: \"{{repo}}-${AGENTPANE_TEST_SUFFIX}\"\nlayout:\n  panes:\n    - type: codex\n      title: \"agent-{{index}}\"\n    - type: claude\n      title: \"agent-{{index}}\"\n")
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), repoCfg, 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadAll(repo)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	if loaded.Repo.Session != "myrepo-dev" {
+		t.Fatalf("expected session myrepo-dev, got %s", loaded.Repo.Session)
+	}
+	if loaded.Repo.Layout.Panes[1].Title != "agent-2" {
+		t.Fatalf("expected title agent-2, got %s", loaded.Repo.Layout.Panes[1].Title)
+	}
+}
+
+func TestLoadAllExpandsSpacedBranchPlaceholder(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+
+	repo := filepath.Join(tmp, "myrepo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "feat-x"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	repoCfg := []byte("session: \"{{ branch }}\"\nlayout:\n  panes:\n    - type: codex\n")
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), repoCfg, 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadAll(repo)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	if loaded.Repo.Session != "feat-x" {
+		t.Fatalf("expected session feat-x, got %q", loaded.Repo.Session)
+	}
+}
@@ -2,7 +2,11 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,9 +18,34 @@ type Loaded struct {
 	Merged     *Config
 	RepoPath   string
 	GlobalPath string
+	// Profile is the name of the active profile, empty when none is selected.
+	Profile string
+	// RawEnv is Merged.Env as written in the config, before ${VAR} and
+	// {{...}} placeholders were expanded.
+	RawEnv map[string]string
 }
 
+// ProfileEnvVar selects a profile when no explicit profile is given.
+const ProfileEnvVar = "AGENTPANE_PROFILE"
+
 func LoadAll(cwd string) (*Loaded, error) {
+	return LoadAllWithProfile(cwd, "")
+}
+
+// LoadAllWithProfile loads config like LoadAll, activating the named profile.
+// When profile is empty, AGENTPANE_PROFILE and then the repo config's
+// profile key are consulted.
+func LoadAllWithProfile(cwd, profile string) (*Loaded, error) {
+	return load(cwd, profile, true)
+}
+
+// LoadGlobalWithProfile loads the builtin and global config, ignoring any
+// repo config around dir. dir is only used to expand placeholders.
+func LoadGlobalWithProfile(dir, profile string) (*Loaded, error) {
+	return load(dir, profile, false)
+}
+
+func load(cwd, profile string, withRepo bool) (*Loaded, error) {
 	builtins, err := LoadBuiltinTemplates()
 	if err != nil {
 		return nil, err
@@ -29,20 +58,26 @@ func LoadAll(cwd string) (*Loaded, error) {
 
 	var globalCfg Config
 	globalLoaded := false
+	needsBranch := false
 	if data, err := os.ReadFile(globalPath); err == nil {
 		if err := yaml.Unmarshal(data, &globalCfg); err != nil {
 			return nil, err
 		}
 		globalLoaded = true
+		needsBranch = usesBranch(data)
 	} else if !errors.Is(err, os.ErrNotExist) {
 		return nil, err
 	}
 
 	var repoCfg RepoConfig
 	repoLoaded := false
-	repoPath, found, err := FindRepoConfigPath(cwd)
-	if err != nil {
-		return nil, err
+	var repoPath string
+	found := false
+	if withRepo {
+		var err error
+		if repoPath, found, err = FindRepoConfigPath(cwd); err != nil {
+			return nil, err
+		}
 	}
 	if found {
 		if data, err := os.ReadFile(repoPath); err == nil {
@@ -50,6 +85,7 @@ func LoadAll(cwd string) (*Loaded, error) {
 				return nil, err
 			}
 			repoLoaded = true
+			needsBranch = needsBranch || usesBranch(data)
 		} else if !errors.Is(err, os.ErrNotExist) {
 			return nil, err
 		}
@@ -77,12 +113,59 @@ func LoadAll(cwd string) (*Loaded, error) {
 
 	merged := Merge(base, globalPtr)
 
-	return &Loaded{
+	profileName := resolveProfileName(profile, repoPtr)
+	if profileName != "" {
+		p, ok := merged.Profiles[profileName]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %q (available: %s)", profileName, strings.Join(profileNames(merged), ", "))
+		}
+		merged = MergeProfile(merged, &p)
+	}
+
+	if repoPtr != nil {
+		for name, tmpl := range repoPtr.Templates {
+			merged.Templates[name] = tmpl
+		}
+		merged.Hooks.Down = append(merged.Hooks.Down, repoPtr.Hooks.Down...)
+	}
+
+	loaded := &Loaded{
 		Global:     globalPtr,
 		Repo:       repoPtr,
 		Builtins:   builtins,
 		Merged:     merged,
 		RepoPath:   repoPath,
 		GlobalPath: globalPath,
-	}, nil
+		Profile:    profileName,
+	}
+
+	repoDir := cwd
+	if found {
+		repoDir = filepath.Dir(repoPath)
+	}
+	expandLoaded(loaded, NewExpandContext(repoDir, needsBranch))
+
+	return loaded, nil
+}
+
+func resolveProfileName(explicit string, repo *RepoConfig) string {
+	if name := strings.TrimSpace(explicit); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(os.Getenv(ProfileEnvVar)); name != "" {
+		return name
+	}
+	if repo != nil {
+		return strings.TrimSpace(repo.Profile)
+	}
+	return ""
+}
+
+func profileNames(cfg *Config) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
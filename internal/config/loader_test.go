@@ -47,3 +47,225 @@ func TestLoadAllMergesGlobalAndRepo(t *testing.T) {
 		t.Fatalf("expected 2 panes in repo layout")
 	}
 }
+
+func TestLoadAllAppliesProfile(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv(ProfileEnvVar, "")
+
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		t.Fatalf("global path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir global: %v", err)
+	}
+	global := []byte(`default_template: duo
+providers:
+  codex:
+    command: codex
+profiles:
+  cheap:
+    default_template: simple
+    providers:
+      codex:
+        command: codex --model mini
+    env:
+      OPENAI_API_KEY: test
+`)
+	if err := os.WriteFile(globalPath, global, 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	repoCfg := []byte("profile: cheap\nlayout:\n  panes:\n    - type: codex\n")
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), repoCfg, 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadAll(repo)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	if loaded.Profile != "cheap" {
+		t.Fatalf("expected profile cheap from repo config, got %q", loaded.Profile)
+	}
+	if loaded.Merged.DefaultTemplate != "simple" {
+		t.Fatalf("expected profile default_template simple, got %s", loaded.Merged.DefaultTemplate)
+	}
+	if got := loaded.Merged.Providers["codex"].Command; got != "codex --model mini" {
+		t.Fatalf("expected profile provider command, got %s", got)
+	}
+	if loaded.Merged.Env["OPENAI_API_KEY"] != "test" {
+		t.Fatalf("expected profile env to be merged")
+	}
+
+	if _, err := LoadAllWithProfile(repo, "missing"); err == nil {
+		t.Fatalf("expected error for unknown profile")
+	}
+}
+
+func TestLoadAllExpandsProfileEnv(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv(ProfileEnvVar, "")
+	t.Setenv("AGENTPANE_TEST_WORK_KEY", "sk-work")
+
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		t.Fatalf("global path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir global: %v", err)
+	}
+	global := []byte(`env:
+  SESSION_DIR: "/tmp/{{repo}}"
+profiles:
+  work:
+    env:
+      ANTHROPIC_API_KEY: ${AGENTPANE_TEST_WORK_KEY}
+`)
+	if err := os.WriteFile(globalPath, global, 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	loaded, err := LoadAllWithProfile(repo, "work")
+	if err != nil {
+		t.Fatalf("LoadAllWithProfile: %v", err)
+	}
+	if got := loaded.Merged.Env["ANTHROPIC_API_KEY"]; got != "sk-work" {
+		t.Fatalf("expected profile env reference resolved, got %q", got)
+	}
+	if got := loaded.Merged.Env["SESSION_DIR"]; got != "/tmp/repo" {
+		t.Fatalf("expected env placeholder expanded, got %q", got)
+	}
+	if got := loaded.RawEnv["ANTHROPIC_API_KEY"]; got != "${AGENTPANE_TEST_WORK_KEY}" {
+		t.Fatalf("expected raw env kept, got %q", got)
+	}
+	if got := loaded.Global.Profiles["work"].Env["ANTHROPIC_API_KEY"]; got != "${AGENTPANE_TEST_WORK_KEY}" {
+		t.Fatalf("expected global config untouched, got %q", got)
+	}
+}
+
+func TestLoadAllReadsUI(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		t.Fatalf("global path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir global: %v", err)
+	}
+	global := []byte("ui:\n  theme: light\n  keys:\n    kill_session: [K, ctrl+k]\n  colors:\n    primary: \"#5f87ff\"\n")
+	if err := os.WriteFile(globalPath, global, 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+
+	loaded, err := LoadAll(tmp)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	ui := loaded.Merged.UI
+	if ui.Theme != ThemeLight {
+		t.Fatalf("expected light theme, got %q", ui.Theme)
+	}
+	if got := ui.Keys["kill_session"]; len(got) != 2 || got[0] != "K" {
+		t.Fatalf("unexpected kill_session keys: %v", got)
+	}
+	if ui.Colors["primary"] != "#5f87ff" {
+		t.Fatalf("unexpected primary color: %q", ui.Colors["primary"])
+	}
+
+	if err := os.WriteFile(globalPath, []byte("ui:\n  theme: neon\n"), 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+	if _, err := LoadAll(tmp); err == nil {
+		t.Fatalf("expected error for unknown theme")
+	}
+}
+
+func TestLoadAllMergesRepoTemplates(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv(ProfileEnvVar, "")
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	repoCfg := []byte(`templates:
+  duo:
+    layout: main-vertical
+    panes:
+      - type: claude
+        command: claude --model opus
+      - type: shell
+        title: server
+`)
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), repoCfg, 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadAll(repo)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	tmpl := loaded.Merged.Templates["duo"]
+	if tmpl.Layout != "main-vertical" || len(tmpl.Panes) != 2 {
+		t.Fatalf("expected repo template to override builtin duo, got %#v", tmpl)
+	}
+	if tmpl.Panes[0].Command != "claude --model opus" {
+		t.Fatalf("expected pane command, got %q", tmpl.Panes[0].Command)
+	}
+	if _, ok := loaded.Merged.Templates["trio"]; !ok {
+		t.Fatalf("expected builtin templates to remain")
+	}
+}
+
+func TestLoadAllAppendsRepoHooks(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv(ProfileEnvVar, "")
+
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		t.Fatalf("global path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir global: %v", err)
+	}
+	global := []byte("hooks:\n  down:\n    - echo global\n")
+	if err := os.WriteFile(globalPath, global, 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	repoCfg := []byte("hooks:\n  down:\n    - docker compose down\n")
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), repoCfg, 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadAll(repo)
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	got := loaded.Merged.Hooks.Down
+	if len(got) != 2 || got[0] != "echo global" || got[1] != "docker compose down" {
+		t.Fatalf("expected global then repo hooks, got %v", got)
+	}
+	if len(loaded.Global.Hooks.Down) != 1 {
+		t.Fatalf("expected global config to be left alone, got %v", loaded.Global.Hooks.Down)
+	}
+}
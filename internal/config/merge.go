@@ -9,6 +9,10 @@ func Merge(base *Config, overlay *Config) *Config {
 		DefaultTemplate: base.DefaultTemplate,
 		Providers:       map[string]ProviderConfig{},
 		Templates:       map[string]Template{},
+		Env:             map[string]string{},
+		Profiles:        map[string]Profile{},
+		UI:              mergeUI(base.UI, UIConfig{}),
+		Hooks:           Hooks{Down: append([]string(nil), base.Hooks.Down...)},
 	}
 
 	for k, v := range base.Providers {
@@ -17,6 +21,12 @@ func Merge(base *Config, overlay *Config) *Config {
 	for k, v := range base.Templates {
 		out.Templates[k] = v
 	}
+	for k, v := range base.Env {
+		out.Env[k] = v
+	}
+	for k, v := range base.Profiles {
+		out.Profiles[k] = v
+	}
 
 	if overlay == nil {
 		return out
@@ -33,5 +43,28 @@ func Merge(base *Config, overlay *Config) *Config {
 	for k, v := range overlay.Templates {
 		out.Templates[k] = v
 	}
+	for k, v := range overlay.Env {
+		out.Env[k] = v
+	}
+	for k, v := range overlay.Profiles {
+		out.Profiles[k] = v
+	}
+	out.UI = mergeUI(out.UI, overlay.UI)
+	if len(overlay.Hooks.Down) > 0 {
+		out.Hooks.Down = append([]string(nil), overlay.Hooks.Down...)
+	}
 	return out
 }
+
+// MergeProfile layers a profile on top of an already merged global config.
+// Repo settings are applied after this, so a profile sits between the two.
+func MergeProfile(base *Config, p *Profile) *Config {
+	if p == nil {
+		return Merge(base, nil)
+	}
+	return Merge(base, &Config{
+		DefaultTemplate: p.DefaultTemplate,
+		Providers:       p.Providers,
+		Env:             p.Env,
+	})
+}
@@ -13,6 +13,22 @@ func GlobalConfigPath() (string, error) {
 	return filepath.Join(home, ".config", "agentpane", "config.yml"), nil
 }
 
+func WorkspacesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentpane", "workspaces"), nil
+}
+
+func WorkspacePath(name string) (string, error) {
+	dir, err := WorkspacesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yml"), nil
+}
+
 func RepoConfigPath(cwd string) string {
 	return filepath.Join(cwd, ".agentpane.yml")
 }
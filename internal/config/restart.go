@@ -0,0 +1,38 @@
+package config
+
+import "time"
+
+const (
+	RestartNever     = "never"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+
+	DefaultMaxRestarts    = 5
+	DefaultRestartBackoff = 5 * time.Second
+	// MaxRestartsLimit is the highest max_restarts accepted; the restart log
+	// in state keeps as many entries.
+	MaxRestartsLimit = 20
+)
+
+// Resolve fills unset fields of p from fallback and then from defaults.
+func (p RestartPolicy) Resolve(fallback RestartPolicy) RestartPolicy {
+	if p.Restart == "" {
+		p.Restart = fallback.Restart
+	}
+	if p.Restart == "" {
+		p.Restart = RestartNever
+	}
+	if p.MaxRestarts == 0 {
+		p.MaxRestarts = fallback.MaxRestarts
+	}
+	if p.MaxRestarts == 0 {
+		p.MaxRestarts = DefaultMaxRestarts
+	}
+	if p.RestartBackoff == "" {
+		p.RestartBackoff = fallback.RestartBackoff
+	}
+	if p.RestartBackoff == "" {
+		p.RestartBackoff = DefaultRestartBackoff.String()
+	}
+	return p
+}
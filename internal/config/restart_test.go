@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestValidateRestartPolicy(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  RestartPolicy
+		wantErr bool
+	}{
+		{"empty", RestartPolicy{}, false},
+		{"on-failure", RestartPolicy{Restart: RestartOnFailure, MaxRestarts: 3, RestartBackoff: "10s"}, false},
+		{"always", RestartPolicy{Restart: RestartAlways}, false},
+		{"unknown restart", RestartPolicy{Restart: "sometimes"}, true},
+		{"negative max", RestartPolicy{Restart: RestartAlways, MaxRestarts: -1}, true},
+		{"max at limit", RestartPolicy{Restart: RestartAlways, MaxRestarts: MaxRestartsLimit}, false},
+		{"max above limit", RestartPolicy{Restart: RestartAlways, MaxRestarts: MaxRestartsLimit + 1}, true},
+		{"bad backoff", RestartPolicy{Restart: RestartAlways, RestartBackoff: "soon"}, true},
+	}
+	for _, c := range cases {
+		err := validateRestartPolicy(c.policy)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: validateRestartPolicy() error = %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestRestartPolicyResolve(t *testing.T) {
+	got := RestartPolicy{MaxRestarts: 2}.Resolve(RestartPolicy{Restart: RestartOnFailure, MaxRestarts: 9})
+	want := RestartPolicy{Restart: RestartOnFailure, MaxRestarts: 2, RestartBackoff: DefaultRestartBackoff.String()}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if got := (RestartPolicy{}).Resolve(RestartPolicy{}); got.Restart != RestartNever || got.MaxRestarts != DefaultMaxRestarts {
+		t.Fatalf("expected defaults, got %+v", got)
+	}
+}
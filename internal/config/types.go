@@ -5,27 +5,88 @@ type Config struct {
 	DefaultTemplate string                    `yaml:"default_template"`
 	Providers       map[string]ProviderConfig `yaml:"providers"`
 	Templates       map[string]Template       `yaml:"templates"`
+	Env             map[string]string         `yaml:"env,omitempty"`
+	Profiles        map[string]Profile        `yaml:"profiles,omitempty"`
+	UI              UIConfig                  `yaml:"ui,omitempty"`
+	Hooks           Hooks                     `yaml:"hooks,omitempty"`
+}
+
+// Hooks are shell commands run at points in a session's life. They run with
+// sh -c in the session directory, with AGENTPANE_SESSION set.
+type Hooks struct {
+	// Down runs after `agentpane down` has killed the session. Global hooks
+	// run before repo hooks.
+	Down []string `yaml:"down,omitempty"`
+}
+
+// Profile is a named set of overrides layered on top of the global config.
+type Profile struct {
+	DefaultTemplate string                    `yaml:"default_template,omitempty"`
+	Providers       map[string]ProviderConfig `yaml:"providers,omitempty"`
+	Env             map[string]string         `yaml:"env,omitempty"`
 }
 
 type ProviderConfig struct {
 	Command string `yaml:"command"`
+	// Quit is typed into the agent, followed by Enter, to ask it to exit
+	// before `agentpane down` kills its session.
+	Quit          string `yaml:"quit,omitempty"`
+	RestartPolicy `yaml:",inline"`
+}
+
+// RestartPolicy controls whether `agentpane supervise` relaunches exited
+// agents. Fields set on a pane override those set on its provider.
+type RestartPolicy struct {
+	Restart        string `yaml:"restart,omitempty"`         // never, on-failure or always
+	MaxRestarts    int    `yaml:"max_restarts,omitempty"`    // defaults to 5
+	RestartBackoff string `yaml:"restart_backoff,omitempty"` // e.g. "10s", doubled after each restart
 }
 
 type Template struct {
-	Description string     `yaml:"description"`
+	Description string     `yaml:"description,omitempty"`
 	Panes       []PaneSpec `yaml:"panes"`
+	// Layout is a tmux layout, either a preset like "tiled" or a layout
+	// string saved from a window. Defaults to tiled (even-horizontal for
+	// two panes).
+	Layout string `yaml:"layout,omitempty"`
 }
 
 type PaneSpec struct {
 	Type  string `yaml:"type"`
 	Title string `yaml:"title,omitempty"`
+	// Command overrides the provider command for this pane.
+	Command string `yaml:"command,omitempty"`
+	// RawCommand is Command as written in the config, set only when
+	// expanding placeholders changed it.
+	RawCommand string `yaml:"-"`
+	// Path is the pane's starting directory. It is filled in for workspace
+	// panes; other panes start in the session directory.
+	Path          string `yaml:"-"`
+	RestartPolicy `yaml:",inline"`
 }
 
 type RepoConfig struct {
 	Session string `yaml:"session,omitempty"`
-	Layout  Layout `yaml:"layout"`
+	Profile string `yaml:"profile,omitempty"`
+	Layout  Layout `yaml:"layout,omitempty"`
+	// Templates are available to sessions in this repo and override global
+	// templates of the same name.
+	Templates map[string]Template `yaml:"templates,omitempty"`
+	Hooks     Hooks               `yaml:"hooks,omitempty"`
 }
 
 type Layout struct {
 	Panes []PaneSpec `yaml:"panes"`
 }
+
+// Workspace groups several repos into a single session.
+type Workspace struct {
+	Session string          `yaml:"session,omitempty"`
+	Repos   []WorkspaceRepo `yaml:"repos"`
+}
+
+type WorkspaceRepo struct {
+	Path string `yaml:"path"`
+	// Panes defaults to the repo's own layout when empty.
+	Panes []PaneSpec `yaml:"panes,omitempty"`
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UIConfig customizes the dashboard's key bindings and colors.
+type UIConfig struct {
+	// Keys maps a dashboard action to the keys that trigger it, replacing
+	// the action's default keys.
+	Keys   map[string][]string `yaml:"keys,omitempty"`
+	Theme  string              `yaml:"theme,omitempty"`  // dark (default), light or none
+	Colors map[string]string   `yaml:"colors,omitempty"` // role -> color, overriding the theme
+}
+
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+	ThemeNone  = "none"
+)
+
+// ColorRoles are the keys accepted under ui.colors.
+var ColorRoles = []string{"primary", "secondary", "success", "warning", "error", "highlight", "accent", "border"}
+
+func validateUI(ui UIConfig) error {
+	switch ui.Theme {
+	case "", ThemeDark, ThemeLight, ThemeNone:
+	default:
+		return fmt.Errorf("ui.theme invalid: %q (expected dark, light, none)", ui.Theme)
+	}
+	roles := make(map[string]bool, len(ColorRoles))
+	for _, r := range ColorRoles {
+		roles[r] = true
+	}
+	for role := range ui.Colors {
+		if !roles[role] {
+			return fmt.Errorf("ui.colors key invalid: %q (expected %s)", role, strings.Join(ColorRoles, ", "))
+		}
+	}
+	for _, action := range sortedKeys(ui.Keys) {
+		if len(ui.Keys[action]) == 0 {
+			return fmt.Errorf("ui.keys.%s must list at least one key", action)
+		}
+	}
+	return nil
+}
+
+func mergeUI(base, overlay UIConfig) UIConfig {
+	out := UIConfig{
+		Keys:   map[string][]string{},
+		Theme:  base.Theme,
+		Colors: map[string]string{},
+	}
+	for k, v := range base.Keys {
+		out.Keys[k] = v
+	}
+	for k, v := range base.Colors {
+		out.Colors[k] = v
+	}
+	if overlay.Theme != "" {
+		out.Theme = overlay.Theme
+	}
+	for k, v := range overlay.Keys {
+		out.Keys[k] = v
+	}
+	for k, v := range overlay.Colors {
+		out.Colors[k] = v
+	}
+	return out
+}
+
+// sortedKeys keeps validation errors stable across runs.
+func sortedKeys(m map[string][]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
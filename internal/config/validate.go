@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"time"
 )
 
+var validTemplateName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
 var validPaneTypes = map[string]bool{
 	"codex":  true,
 	"claude": true,
@@ -18,10 +22,13 @@ func ValidateGlobal(cfg *Config) error {
 		return fmt.Errorf("invalid default_pane_type %q", cfg.DefaultPaneType)
 	}
 	if cfg.Providers != nil {
-		for k := range cfg.Providers {
+		for k, p := range cfg.Providers {
 			if !validPaneTypes[k] {
 				return fmt.Errorf("invalid providers key %q (expected codex, claude, shell)", k)
 			}
+			if err := validateRestartPolicy(p.RestartPolicy); err != nil {
+				return fmt.Errorf("providers.%s: %w", k, err)
+			}
 		}
 	}
 	for name, tmpl := range cfg.Templates {
@@ -29,20 +36,55 @@ func ValidateGlobal(cfg *Config) error {
 			return err
 		}
 	}
-	return nil
+	for name, p := range cfg.Profiles {
+		for k := range p.Providers {
+			if !validPaneTypes[k] {
+				return fmt.Errorf("profile %q has invalid providers key %q (expected codex, claude, shell)", name, k)
+			}
+		}
+	}
+	return validateUI(cfg.UI)
 }
 
 func ValidateRepo(rc *RepoConfig) error {
 	if rc == nil {
 		return nil
 	}
-	if len(rc.Layout.Panes) == 0 {
+	if len(rc.Layout.Panes) == 0 && len(rc.Templates) == 0 && len(rc.Hooks.Down) == 0 {
 		return fmt.Errorf("repo config layout.panes must not be empty")
 	}
+	for name, tmpl := range rc.Templates {
+		if err := validateTemplate(name, tmpl); err != nil {
+			return fmt.Errorf("repo config: %w", err)
+		}
+	}
 	for i, p := range rc.Layout.Panes {
 		if !validPaneTypes[p.Type] {
 			return fmt.Errorf("repo config layout.panes[%d].type invalid: %q", i, p.Type)
 		}
+		if err := validateRestartPolicy(p.RestartPolicy); err != nil {
+			return fmt.Errorf("repo config layout.panes[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func ValidateWorkspace(name string, ws *Workspace) error {
+	if ws == nil {
+		return nil
+	}
+	if len(ws.Repos) == 0 {
+		return fmt.Errorf("workspace %q repos must not be empty", name)
+	}
+	for i, r := range ws.Repos {
+		if r.Path == "" {
+			return fmt.Errorf("workspace %q repos[%d].path must not be empty", name, i)
+		}
+		for j, p := range r.Panes {
+			if !validPaneTypes[p.Type] {
+				return fmt.Errorf("workspace %q repos[%d].panes[%d].type invalid: %q", name, i, j, p.Type)
+			}
+		}
 	}
 	return nil
 }
@@ -55,6 +97,37 @@ func validateTemplate(name string, tmpl Template) error {
 		if !validPaneTypes[p.Type] {
 			return fmt.Errorf("template %q panes[%d].type invalid: %q", name, i, p.Type)
 		}
+		if err := validateRestartPolicy(p.RestartPolicy); err != nil {
+			return fmt.Errorf("template %q panes[%d]: %w", name, i, err)
+		}
+	}
+	return nil
+}
+
+func validateRestartPolicy(p RestartPolicy) error {
+	switch p.Restart {
+	case "", RestartNever, RestartOnFailure, RestartAlways:
+	default:
+		return fmt.Errorf("invalid restart %q (expected never, on-failure, always)", p.Restart)
+	}
+	if p.MaxRestarts < 0 {
+		return fmt.Errorf("max_restarts must not be negative")
+	}
+	if p.MaxRestarts > MaxRestartsLimit {
+		return fmt.Errorf("max_restarts must be at most %d", MaxRestartsLimit)
+	}
+	if p.RestartBackoff != "" {
+		if _, err := time.ParseDuration(p.RestartBackoff); err != nil {
+			return fmt.Errorf("invalid restart_backoff %q: %w", p.RestartBackoff, err)
+		}
 	}
 	return nil
 }
+
+// ValidateTemplate checks a template before it is saved to the config.
+func ValidateTemplate(name string, tmpl Template) error {
+	if !validTemplateName.MatchString(name) {
+		return fmt.Errorf("invalid template name %q (use letters, digits, '-', '_' and '.')", name)
+	}
+	return validateTemplate(name, tmpl)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadWorkspace reads ~/.config/agentpane/workspaces/<name>.yml. Repo paths
+// are expanded (~, ${VAR}) and made absolute relative to the workspace file.
+func LoadWorkspace(name string) (*Workspace, error) {
+	path, err := WorkspacePath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("workspace %q not found (expected %s)", name, path)
+		}
+		return nil, err
+	}
+
+	var ws Workspace
+	if err := yaml.Unmarshal(data, &ws); err != nil {
+		return nil, err
+	}
+	if err := ValidateWorkspace(name, &ws); err != nil {
+		return nil, err
+	}
+
+	ctx := NewExpandContext(filepath.Dir(path), false)
+	for i := range ws.Repos {
+		ws.Repos[i].Path = resolveWorkspacePath(filepath.Dir(path), Expand(ws.Repos[i].Path, ctx, 0))
+		ws.Repos[i].Panes = ExpandPanes(ws.Repos[i].Panes, NewExpandContext(ws.Repos[i].Path, false))
+	}
+	// The session spans several repos; {{repo}} in its name means the first.
+	ws.Session = Expand(ws.Session, NewExpandContext(ws.Repos[0].Path, false), 0)
+	return &ws, nil
+}
+
+func resolveWorkspacePath(base, p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(base, p)
+	}
+	return filepath.Clean(p)
+}
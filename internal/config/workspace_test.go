@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveWorkspacePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cases := []struct {
+		base, in, want string
+	}{
+		{"/ws", "~", home},
+		{"/ws", "~/code/api", filepath.Join(home, "code/api")},
+		{"/ws", "/abs/repo/", "/abs/repo"},
+		{"/ws", "../web", "/web"},
+		{"/ws", "api", "/ws/api"},
+	}
+	for _, c := range cases {
+		if got := resolveWorkspacePath(c.base, c.in); got != c.want {
+			t.Errorf("resolveWorkspacePath(%q, %q) = %q, want %q", c.base, c.in, got, c.want)
+		}
+	}
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("AGENTPANE_TEST_CODE", filepath.Join(home, "code"))
+
+	path, err := WorkspacePath("full")
+	if err != nil {
+		t.Fatalf("workspace path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	data := []byte(`session: "fullstack-{{repo}}"
+repos:
+  - path: ${AGENTPANE_TEST_CODE}/api
+    panes:
+      - type: claude
+        title: "{{repo}}-{{index}}"
+  - path: ~/code/web
+  - path: local
+`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write workspace: %v", err)
+	}
+
+	ws, err := LoadWorkspace("full")
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	if ws.Session != "fullstack-api" {
+		t.Fatalf("expected session expanded against the first repo, got %q", ws.Session)
+	}
+	want := []string{
+		filepath.Join(home, "code/api"),
+		filepath.Join(home, "code/web"),
+		filepath.Join(filepath.Dir(path), "local"),
+	}
+	for i, w := range want {
+		if ws.Repos[i].Path != w {
+			t.Errorf("repos[%d].path = %q, want %q", i, ws.Repos[i].Path, w)
+		}
+	}
+	if got := ws.Repos[0].Panes[0].Title; got != "api-1" {
+		t.Fatalf("expected pane title expanded against its repo, got %q", got)
+	}
+}
+
+func TestLoadWorkspaceErrors(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if _, err := LoadWorkspace("missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	path, err := WorkspacePath("bad")
+	if err != nil {
+		t.Fatalf("workspace path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("repos:\n  - path: api\n    panes:\n      - type: vim\n"), 0o644); err != nil {
+		t.Fatalf("write workspace: %v", err)
+	}
+	if _, err := LoadWorkspace("bad"); err == nil || !strings.Contains(err.Error(), "type invalid") {
+		t.Fatalf("expected invalid type error, got %v", err)
+	}
+}
+
+func TestLoadGlobalIgnoresRepoConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnvVar, "")
+
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		t.Fatalf("global path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir global: %v", err)
+	}
+	if err := os.WriteFile(globalPath, []byte("env:\n  MODEL: global\n"), 0o644); err != nil {
+		t.Fatalf("write global: %v", err)
+	}
+	repo := filepath.Join(home, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".agentpane.yml"), []byte("profile: missing\n"), 0o644); err != nil {
+		t.Fatalf("write repo: %v", err)
+	}
+
+	loaded, err := LoadGlobalWithProfile(repo, "")
+	if err != nil {
+		t.Fatalf("LoadGlobalWithProfile: %v", err)
+	}
+	if loaded.Repo != nil || loaded.Merged.Env["MODEL"] != "global" {
+		t.Fatalf("expected only global config, got repo=%v env=%v", loaded.Repo, loaded.Merged.Env)
+	}
+}
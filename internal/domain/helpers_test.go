@@ -23,3 +23,17 @@ func TestInferPaneType(t *testing.T) {
 		t.Fatalf("expected unknown, got %s", got)
 	}
 }
+
+func TestSessionNeedsAttention(t *testing.T) {
+	s := Session{Panes: []Pane{
+		{Type: PaneShell, Status: StatusExited},
+		{Type: PaneClaude, Status: StatusActive},
+	}}
+	if s.NeedsAttention() {
+		t.Fatalf("exited shell should not need attention")
+	}
+	s.Panes[1].Status = StatusExited
+	if !s.NeedsAttention() {
+		t.Fatalf("exited agent should need attention")
+	}
+}
@@ -28,14 +28,54 @@ type Pane struct {
 	PID            int
 	CurrentCommand string
 	CurrentPath    string
+	// Path is the directory the pane was started in, as recorded in state.
+	Path     string
+	Restarts int
+	Tags     []string
+	// Note is free text, typically what the agent is working on.
+	Note string
+	// Git is set when the pane is in a different worktree or branch than
+	// its session.
+	Git *GitInfo
 }
 
 type Session struct {
 	Name      string
 	Path      string
+	Profile   string
 	CreatedAt time.Time
 	Attached  bool
-	Panes     []Pane
+	// LastActivity is when tmux last saw input or output in the session.
+	LastActivity time.Time
+	Pinned       bool
+	// Remote is the git remote URL of the session directory, if any.
+	Remote string
+	Tags   []string
+	Note   string
+	// Git is nil when the session directory is not in a git repo.
+	Git   *GitInfo
+	Panes []Pane
+}
+
+// GitInfo is the repository state of a session or pane directory.
+type GitInfo struct {
+	Root    string
+	Branch  string // empty when HEAD is detached
+	Head    string // short commit hash
+	Dirty   int
+	Ahead   int
+	Behind  int
+	Subject string
+}
+
+// NeedsAttention reports whether an agent in the session has exited.
+func (s Session) NeedsAttention() bool {
+	for _, p := range s.Panes {
+		if p.Status == StatusExited && p.Type != PaneShell {
+			return true
+		}
+	}
+	return false
 }
 
 type Snapshot struct {
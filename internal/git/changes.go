@@ -0,0 +1,216 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Change is a file with uncommitted changes.
+type Change struct {
+	Path      string // relative to the repo root
+	OrigPath  string // path before a staged rename, empty otherwise
+	Staged    bool   // has changes in the index
+	Unstaged  bool   // has changes in the work tree
+	Untracked bool
+	Added     bool // new in the index, not in HEAD
+	Deleted   bool
+}
+
+// maxUntrackedBytes bounds how much of an untracked file Diff shows.
+const maxUntrackedBytes = 256 * 1024
+
+// Changes lists uncommitted files of the repo containing dir, including
+// untracked ones.
+func Changes(dir string) ([]Change, error) {
+	out, err := runGit(dir, "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+	return parseChanges(out), nil
+}
+
+func parseChanges(out string) []Change {
+	var changes []Change
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if len(f) < 4 {
+			continue
+		}
+		x, y, path := f[0], f[1], f[3:]
+		c := Change{Path: path}
+		switch {
+		case x == '?' && y == '?':
+			c.Untracked = true
+		default:
+			c.Staged = x != ' '
+			c.Unstaged = y != ' '
+			c.Added = x == 'A'
+			c.Deleted = x == 'D' || y == 'D'
+		}
+		// Renames and copies are followed by the original path
+		if x == 'R' || x == 'C' {
+			i++
+			if x == 'R' && i < len(fields) {
+				c.OrigPath = fields[i]
+			}
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// paths are the paths a change touches, both sides for a rename.
+func (c Change) paths() []string {
+	if c.OrigPath != "" {
+		return []string{c.OrigPath, c.Path}
+	}
+	return []string{c.Path}
+}
+
+// Diff returns the diff of one file against HEAD, staged and unstaged
+// changes combined. Untracked files are shown as entirely added.
+func Diff(dir string, c Change) (string, error) {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	if c.Untracked {
+		return untrackedDiff(root, c.Path)
+	}
+	args := append([]string{"diff", "--no-color", "-M", "HEAD", "--"}, c.paths()...)
+	out, err := runGit(root, args...)
+	if err != nil && c.Added {
+		// No commits yet, so there is no HEAD to compare against.
+		return runGit(root, "diff", "--no-color", "--cached", "--", c.Path)
+	}
+	return out, err
+}
+
+func untrackedDiff(root, path string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return fmt.Sprintf("new file %s\n(binary file)\n", path), nil
+	}
+	truncated := len(data) > maxUntrackedBytes
+	if truncated {
+		data = data[:maxUntrackedBytes]
+	}
+
+	var b strings.Builder
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	fmt.Fprintf(&b, "new file %s\n@@ -0,0 +1,%d @@\n", path, len(lines))
+	for _, l := range lines {
+		b.WriteString("+" + l + "\n")
+	}
+	if truncated {
+		b.WriteString("(truncated)\n")
+	}
+	return b.String(), nil
+}
+
+// Stage adds a file's changes to the index.
+func Stage(dir string, c Change) error {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return err
+	}
+	_, err = runGit(root, "add", "-A", "--", c.Path)
+	return err
+}
+
+// Discard drops all changes to a file, staged or not. Untracked files are
+// deleted, and a rename is undone by removing the new path and restoring the
+// original one.
+func Discard(dir string, c Change) error {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.Untracked:
+		_, err = runGit(root, "clean", "-f", "--", c.Path)
+	case c.Added:
+		_, err = runGit(root, "rm", "-f", "--", c.Path)
+	case c.OrigPath != "":
+		if _, err = runGit(root, "rm", "-f", "--", c.Path); err == nil {
+			_, err = runGit(root, "restore", "--source=HEAD", "--staged", "--worktree", "--", c.OrigPath)
+		}
+	default:
+		_, err = runGit(root, "restore", "--source=HEAD", "--staged", "--worktree", "--", c.Path)
+	}
+	return err
+}
+
+// Commit commits the staged changes.
+func Commit(dir, message string) error {
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message must not be empty")
+	}
+	_, err := runGit(dir, "commit", "-m", message)
+	return err
+}
+
+func repoRoot(dir string) (string, error) {
+	out, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Snapshot records the work tree as it is now, untracked files included and
+// ignored ones left out, and returns the ID of the resulting tree. It uses a
+// scratch index, so the real index, HEAD and the stash are left alone.
+func Snapshot(dir string) (string, error) {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp("", "agentpane-index-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	index := filepath.Join(tmp, "index")
+
+	// Starting from a copy of the real index lets git skip rehashing files
+	// whose stat data hasn't changed.
+	if out, err := runGit(root, "rev-parse", "--git-path", "index"); err == nil {
+		src := strings.TrimSpace(out)
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(root, src)
+		}
+		if data, err := os.ReadFile(src); err == nil {
+			if err := os.WriteFile(index, data, 0o600); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + index}
+	if _, err := runGitEnv(root, env, "add", "-A"); err != nil {
+		return "", err
+	}
+	out, err := runGitEnv(root, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// DiffSince returns every change to the work tree since a tree taken by
+// Snapshot, whether committed since or not, untracked files included.
+func DiffSince(dir, snapshot string) (string, error) {
+	now, err := Snapshot(dir)
+	if err != nil {
+		return "", err
+	}
+	return runGit(dir, "diff", "--no-color", snapshot, now)
+}
@@ -0,0 +1,235 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRepo creates a repo with one commit holding the given files.
+func testRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "-q")
+	for name, content := range files {
+		writeFile(t, dir, name, content)
+	}
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "-q", "--allow-empty", "-m", "init")
+	return dir
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if _, err := runGit(dir, args...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// onlyChange returns the single change in dir.
+func onlyChange(t *testing.T, dir string) Change {
+	t.Helper()
+	changes, err := Changes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %+v", changes)
+	}
+	return changes[0]
+}
+
+func assertClean(t *testing.T, dir string) {
+	t.Helper()
+	changes, err := Changes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected a clean tree, got %+v", changes)
+	}
+}
+
+func TestStageAndDiscard(t *testing.T) {
+	dir := testRepo(t, map[string]string{"main.go": "package main\n"})
+	writeFile(t, dir, "main.go", "package main\n\nfunc main() {}\n")
+
+	c := onlyChange(t, dir)
+	if !c.Unstaged || c.Staged {
+		t.Fatalf("expected an unstaged change, got %+v", c)
+	}
+	diff, err := Diff(dir, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "+func main() {}") {
+		t.Fatalf("diff is missing the new line:\n%s", diff)
+	}
+
+	if err := Stage(dir, c); err != nil {
+		t.Fatal(err)
+	}
+	if c = onlyChange(t, dir); !c.Staged || c.Unstaged {
+		t.Fatalf("expected a staged change, got %+v", c)
+	}
+
+	if err := Discard(dir, c); err != nil {
+		t.Fatal(err)
+	}
+	assertClean(t, dir)
+	if got := readFile(t, dir, "main.go"); got != "package main\n" {
+		t.Fatalf("main.go not restored: %q", got)
+	}
+}
+
+func TestDiscardRename(t *testing.T) {
+	dir := testRepo(t, map[string]string{"old.go": "package main\n"})
+	gitRun(t, dir, "mv", "old.go", "new.go")
+	writeFile(t, dir, "new.go", "package main\n\n// moved\n")
+
+	c := onlyChange(t, dir)
+	if c.Path != "new.go" || c.OrigPath != "old.go" {
+		t.Fatalf("expected a rename of old.go to new.go, got %+v", c)
+	}
+	diff, err := Diff(dir, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "rename from old.go") || strings.Contains(diff, "new file") {
+		t.Fatalf("diff does not show a rename:\n%s", diff)
+	}
+
+	if err := Discard(dir, c); err != nil {
+		t.Fatal(err)
+	}
+	assertClean(t, dir)
+	if got := readFile(t, dir, "old.go"); got != "package main\n" {
+		t.Fatalf("old.go not restored: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.go")); !os.IsNotExist(err) {
+		t.Fatalf("new.go still exists: %v", err)
+	}
+}
+
+func TestStageRename(t *testing.T) {
+	dir := testRepo(t, map[string]string{"old.go": "package main\n"})
+	gitRun(t, dir, "mv", "old.go", "new.go")
+	writeFile(t, dir, "new.go", "package main\n\n// moved\n")
+
+	if err := Stage(dir, onlyChange(t, dir)); err != nil {
+		t.Fatal(err)
+	}
+	c := onlyChange(t, dir)
+	if c.OrigPath != "old.go" || !c.Staged || c.Unstaged {
+		t.Fatalf("expected a fully staged rename, got %+v", c)
+	}
+}
+
+func TestDiscardAddedAndUntracked(t *testing.T) {
+	dir := testRepo(t, nil)
+	writeFile(t, dir, "added.go", "package main\n")
+	gitRun(t, dir, "add", "added.go")
+	writeFile(t, dir, "scratch.txt", "notes\n")
+
+	changes, err := Changes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if err := Discard(dir, c); err != nil {
+			t.Fatalf("discard %s: %v", c.Path, err)
+		}
+	}
+	assertClean(t, dir)
+	for _, name := range []string{"added.go", "scratch.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Fatalf("%s still exists: %v", name, err)
+		}
+	}
+}
+
+func TestUntrackedDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "notes.txt", "one\ntwo\n")
+	writeFile(t, dir, "blob.bin", "a\x00b")
+
+	got, err := untrackedDiff(dir, "notes.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "new file notes.txt\n@@ -0,0 +1,2 @@\n+one\n+two\n"; got != want {
+		t.Fatalf("untrackedDiff = %q, want %q", got, want)
+	}
+
+	got, err = untrackedDiff(dir, "blob.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "new file blob.bin\n(binary file)\n"; got != want {
+		t.Fatalf("untrackedDiff = %q, want %q", got, want)
+	}
+}
+
+func TestDiffSinceSnapshot(t *testing.T) {
+	dir := testRepo(t, map[string]string{"a.txt": "a\n", "b.txt": "b\n"})
+	writeFile(t, dir, "a.txt", "a before\n")
+	writeFile(t, dir, "old.txt", "untracked before\n")
+
+	snapshot, err := Snapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "b.txt", "b during\n")
+	writeFile(t, dir, "new.txt", "new\n")
+
+	diff, err := DiffSince(dir, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"+b during", "+new"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+	for _, unwanted := range []string{"a.txt", "old.txt"} {
+		if strings.Contains(diff, unwanted) {
+			t.Errorf("diff includes pre-existing change to %s:\n%s", unwanted, diff)
+		}
+	}
+	changes, err := Changes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Staged {
+			t.Errorf("snapshot staged %+v", c)
+		}
+	}
+}
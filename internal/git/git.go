@@ -0,0 +1,172 @@
+// Package git reads repository status for session and pane directories.
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status summarizes the working tree containing a directory.
+type Status struct {
+	Root    string // top-level directory of the worktree
+	Branch  string // empty when HEAD is detached
+	Head    string // short commit hash
+	Dirty   int    // changed, staged or untracked files
+	Ahead   int
+	Behind  int
+	Subject string // subject of the HEAD commit
+}
+
+// DefaultTTL is how long a directory's status is reused before git runs again.
+const DefaultTTL = 10 * time.Second
+
+type entry struct {
+	status  Status
+	ok      bool
+	remote  string
+	fetched time.Time
+}
+
+// Reader caches git lookups per directory so periodic refreshes don't spawn
+// git on every tick. It is safe for concurrent use.
+type Reader struct {
+	ttl time.Duration
+	now func() time.Time
+	run func(dir string, args ...string) (string, error)
+
+	mu    sync.Mutex
+	cache map[string]entry
+}
+
+func NewReader(ttl time.Duration) *Reader {
+	return &Reader{
+		ttl:   ttl,
+		now:   time.Now,
+		run:   runGit,
+		cache: make(map[string]entry),
+	}
+}
+
+// Status returns the status of the repo containing dir. ok is false when dir
+// is not inside a git work tree.
+func (r *Reader) Status(dir string) (Status, bool) {
+	e := r.lookup(dir)
+	return e.status, e.ok
+}
+
+// Invalidate drops cached results so the next lookup runs git again, e.g.
+// after staging or committing.
+func (r *Reader) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[string]entry)
+}
+
+// Remote returns the origin URL of the repo containing dir, if any.
+func (r *Reader) Remote(dir string) string {
+	return r.lookup(dir).remote
+}
+
+func (r *Reader) lookup(dir string) entry {
+	if dir == "" {
+		return entry{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.cache[dir]; ok && r.now().Sub(e.fetched) < r.ttl {
+		return e
+	}
+
+	e := entry{fetched: r.now()}
+	if out, err := r.run(dir, "status", "--porcelain=v2", "--branch"); err == nil {
+		e.status = parseStatus(out)
+		e.ok = true
+		if root, err := r.run(dir, "rev-parse", "--show-toplevel"); err == nil {
+			e.status.Root = strings.TrimSpace(root)
+		}
+		if subject, err := r.run(dir, "log", "-1", "--format=%s"); err == nil {
+			e.status.Subject = strings.TrimSpace(subject)
+		}
+		if remote, err := r.run(dir, "remote", "get-url", "origin"); err == nil {
+			e.remote = strings.TrimSpace(remote)
+		}
+	}
+	r.cache[dir] = e
+	return e
+}
+
+// parseStatus reads `git status --porcelain=v2 --branch` output.
+func parseStatus(out string) Status {
+	var s Status
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			oid := strings.TrimPrefix(line, "# branch.oid ")
+			if len(oid) > 7 {
+				oid = oid[:7]
+			}
+			if oid != "(initial)" {
+				s.Head = oid
+			}
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				s.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.ab "):
+			for _, f := range strings.Fields(strings.TrimPrefix(line, "# branch.ab ")) {
+				n, err := strconv.Atoi(f[1:])
+				if err != nil {
+					continue
+				}
+				switch f[0] {
+				case '+':
+					s.Ahead = n
+				case '-':
+					s.Behind = n
+				}
+			}
+		case strings.HasPrefix(line, "#"), line == "":
+		default:
+			s.Dirty++
+		}
+	}
+	return s
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	return runGitEnv(dir, nil, args...)
+}
+
+// runGitEnv is runGit with extra environment variables.
+func runGitEnv(dir string, env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			// Some failures, like "nothing to commit", are reported on stdout.
+			msg = strings.TrimSpace(stdout.String())
+		}
+		if msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
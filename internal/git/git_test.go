@@ -0,0 +1,91 @@
+package git
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	out := "# branch.oid 1234567890abcdef\n" +
+		"# branch.head feature\n" +
+		"# branch.upstream origin/feature\n" +
+		"# branch.ab +2 -3\n" +
+		"1 .M N... 100644 100644 100644 abc abc main.go\n" +
+		"? notes.txt\n"
+
+	s := parseStatus(out)
+	if s.Branch != "feature" || s.Head != "1234567" {
+		t.Fatalf("unexpected branch/head: %#v", s)
+	}
+	if s.Ahead != 2 || s.Behind != 3 || s.Dirty != 2 {
+		t.Fatalf("unexpected counts: %#v", s)
+	}
+}
+
+func TestParseStatusDetached(t *testing.T) {
+	s := parseStatus("# branch.oid abcdef0123\n# branch.head (detached)\n")
+	if s.Branch != "" || s.Head != "abcdef0" || s.Dirty != 0 {
+		t.Fatalf("unexpected status: %#v", s)
+	}
+}
+
+func TestReaderCachesWithinTTL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calls := 0
+	r := NewReader(10 * time.Second)
+	r.now = func() time.Time { return now }
+	r.run = func(dir string, args ...string) (string, error) {
+		calls++
+		if dir == "/not-a-repo" {
+			return "", errors.New("not a git repository")
+		}
+		if args[0] == "status" {
+			return "# branch.head main\n", nil
+		}
+		return "", nil
+	}
+
+	if s, ok := r.Status("/repo"); !ok || s.Branch != "main" {
+		t.Fatalf("unexpected status: %#v ok=%v", s, ok)
+	}
+	first := calls
+	r.Status("/repo")
+	r.Remote("/repo")
+	if calls != first {
+		t.Fatalf("expected cached result, git ran %d more times", calls-first)
+	}
+
+	now = now.Add(11 * time.Second)
+	r.Status("/repo")
+	if calls == first {
+		t.Fatalf("expected refresh after TTL")
+	}
+
+	if _, ok := r.Status("/not-a-repo"); ok {
+		t.Fatalf("expected ok=false outside a repo")
+	}
+}
+
+func TestParseChanges(t *testing.T) {
+	out := " M main.go\x00A  new.go\x00R  renamed.go\x00old.go\x00?? notes.txt\x00MM both.go\x00"
+	changes := parseChanges(out)
+	if len(changes) != 5 {
+		t.Fatalf("expected 5 changes, got %#v", changes)
+	}
+	if c := changes[0]; c.Path != "main.go" || c.Staged || !c.Unstaged {
+		t.Fatalf("unexpected modified change: %#v", c)
+	}
+	if c := changes[1]; !c.Added || !c.Staged {
+		t.Fatalf("unexpected added change: %#v", c)
+	}
+	if c := changes[2]; c.Path != "renamed.go" || c.OrigPath != "old.go" {
+		t.Fatalf("rename should use the new path and keep the old one: %#v", c)
+	}
+	if c := changes[3]; !c.Untracked || c.Path != "notes.txt" {
+		t.Fatalf("unexpected untracked change: %#v", c)
+	}
+	if c := changes[4]; !c.Staged || !c.Unstaged {
+		t.Fatalf("unexpected staged+unstaged change: %#v", c)
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import "time"
+
+// ActivityTracker tells agents that are working from ones waiting for input
+// by watching their pane output: a pane is idle once its visible content has
+// not changed for the quiet period. Agents redraw a spinner or timer while
+// they work, so a still screen means they are waiting.
+type ActivityTracker struct {
+	quiet time.Duration
+	panes map[string]paneActivity
+}
+
+type paneActivity struct {
+	content string
+	changed time.Time
+}
+
+func NewActivityTracker(quiet time.Duration) *ActivityTracker {
+	return &ActivityTracker{quiet: quiet, panes: make(map[string]paneActivity)}
+}
+
+// Observe records a pane's current content and reports whether the pane is
+// idle. The first observation of a pane counts as a change.
+func (t *ActivityTracker) Observe(paneID, content string, now time.Time) bool {
+	prev, ok := t.panes[paneID]
+	if !ok || prev.content != content {
+		t.panes[paneID] = paneActivity{content: content, changed: now}
+		return false
+	}
+	return now.Sub(prev.changed) >= t.quiet
+}
+
+// QuietFor returns how long a pane's content has been unchanged.
+func (t *ActivityTracker) QuietFor(paneID string, now time.Time) time.Duration {
+	prev, ok := t.panes[paneID]
+	if !ok {
+		return 0
+	}
+	return now.Sub(prev.changed)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityTrackerIdleAfterQuietPeriod(t *testing.T) {
+	tr := NewActivityTracker(10 * time.Second)
+	start := time.Now()
+
+	if tr.Observe("%1", "working |", start) {
+		t.Fatalf("first observation should not be idle")
+	}
+	if tr.Observe("%1", "working /", start.Add(5*time.Second)) {
+		t.Fatalf("changed content should not be idle")
+	}
+	if tr.Observe("%1", "working /", start.Add(14*time.Second)) {
+		t.Fatalf("expected busy 9s after the last change")
+	}
+	if !tr.Observe("%1", "working /", start.Add(15*time.Second)) {
+		t.Fatalf("expected idle 10s after the last change")
+	}
+	if got := tr.QuietFor("%1", start.Add(20*time.Second)); got != 15*time.Second {
+		t.Fatalf("expected quiet for 15s, got %s", got)
+	}
+	if tr.Observe("%2", "other", start.Add(30*time.Second)) {
+		t.Fatalf("panes should be tracked separately")
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"os"
 	"os/exec"
+	"sort"
 
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
 )
@@ -12,6 +13,8 @@ type Provider struct {
 	Command     string
 	TitlePrefix string
 	Executable  string
+	// Quit is typed into the agent to ask it to exit; empty for shells.
+	Quit string
 }
 
 type Registry struct {
@@ -27,12 +30,14 @@ func NewRegistry() *Registry {
 				Command:     "codex",
 				TitlePrefix: "codex-",
 				Executable:  "codex",
+				Quit:        "/quit",
 			},
 			domain.PaneClaude: {
 				Type:        domain.PaneClaude,
 				Command:     "claude",
 				TitlePrefix: "claude-",
 				Executable:  "claude",
+				Quit:        "/exit",
 			},
 			domain.PaneShell: {
 				Type:        domain.PaneShell,
@@ -58,6 +63,16 @@ func (r *Registry) Get(t domain.PaneType) (*Provider, bool) {
 	return p, true
 }
 
+// Types lists the registered pane types in name order.
+func (r *Registry) Types() []domain.PaneType {
+	out := make([]domain.PaneType, 0, len(r.providers))
+	for t := range r.providers {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
 func (r *Registry) IsAvailable(t domain.PaneType) bool {
 	p, ok := r.providers[t]
 	if !ok {
@@ -34,3 +34,16 @@ func TestOverrideCommand(t *testing.T) {
 		t.Fatalf("expected override command, got %s", p.Command)
 	}
 }
+
+func TestTypesSorted(t *testing.T) {
+	got := NewRegistry().Types()
+	want := []domain.PaneType{domain.PaneClaude, domain.PaneCodex, domain.PaneShell}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -12,3 +12,22 @@ func DefaultPath() (string, error) {
 	}
 	return filepath.Join(home, ".local", "share", "agentpane", "state.yml"), nil
 }
+
+// TranscriptsDir is where pane transcripts are archived, one directory per
+// session.
+func TranscriptsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "agentpane", "transcripts"), nil
+}
+
+// RunsDir is where `agentpane run` saves results, one directory per run.
+func RunsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "agentpane", "runs"), nil
+}
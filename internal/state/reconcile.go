@@ -40,6 +40,9 @@ func Reconcile(input ReconcileInput) ReconcileOutput {
 	if input.CurrentState != nil {
 		output.UpdatedState.Version = input.CurrentState.Version
 		output.UpdatedState.ServerID = input.CurrentState.ServerID
+		output.UpdatedState.Pinned = input.CurrentState.Pinned
+		output.UpdatedState.Tasks = input.CurrentState.Tasks
+		output.UpdatedState.NextTaskID = input.CurrentState.NextTaskID
 	}
 
 	tmuxSessionMap := make(map[string]domain.Session)
@@ -69,13 +72,19 @@ func Reconcile(input ReconcileInput) ReconcileOutput {
 
 func reconcileSession(stateSession *SessionState, tmuxSession domain.Session, output *ReconcileOutput) *SessionState {
 	if !stateSession.CreatedAt.IsZero() && tmuxSession.CreatedAt.After(stateSession.CreatedAt.Add(2*time.Second)) {
-		// tmux session was recreated (e.g., server restart). Drop stale pane IDs.
-		return createSessionState(tmuxSession, output)
+		// tmux session was recreated (e.g., server restart). Drop stale pane
+		// IDs but keep what the user wrote about the session.
+		ss := createSessionState(tmuxSession, output)
+		ss.Tags, ss.Note = stateSession.Tags, stateSession.Note
+		return ss
 	}
 
 	result := &SessionState{
 		Path:      stateSession.Path,
+		Profile:   stateSession.Profile,
 		CreatedAt: stateSession.CreatedAt,
+		Tags:      stateSession.Tags,
+		Note:      stateSession.Note,
 		Panes:     make([]*PaneState, 0),
 	}
 
@@ -53,3 +53,55 @@ func TestReconcileUpdatesTitlesAndAddsNew(t *testing.T) {
 		t.Fatalf("expected 2 panes in updated state")
 	}
 }
+
+func TestReconcileKeepsPinned(t *testing.T) {
+	stateStore := &Store{
+		Version:  1,
+		Sessions: map[string]*SessionState{},
+		Pinned:   []string{"repo", "gone"},
+	}
+
+	output := Reconcile(ReconcileInput{
+		CurrentState: stateStore,
+		TmuxSessions: []domain.Session{{Name: "repo"}},
+	})
+
+	pinned := output.UpdatedState.Pinned
+	if len(pinned) != 2 || pinned[0] != "repo" || pinned[1] != "gone" {
+		t.Fatalf("expected pins to be kept, got %#v", pinned)
+	}
+}
+
+func TestReconcileRecreatedSessionKeepsMetadata(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	stateStore := &Store{
+		Version: 1,
+		Sessions: map[string]*SessionState{
+			"repo": {
+				Path:      "/tmp/repo",
+				CreatedAt: created,
+				Tags:      []string{"infra"},
+				Note:      "migrating the db",
+				Panes:     []*PaneState{{TmuxID: "%0", Type: "codex", Title: "codex-1", Tags: []string{"stale"}}},
+			},
+		},
+	}
+
+	output := Reconcile(ReconcileInput{
+		CurrentState: stateStore,
+		TmuxSessions: []domain.Session{{
+			Name:      "repo",
+			Path:      "/tmp/repo",
+			CreatedAt: time.Now(),
+			Panes:     []domain.Pane{{ID: "%0", Title: "zsh", CurrentCommand: "zsh"}},
+		}},
+	})
+
+	ss := output.UpdatedState.Sessions["repo"]
+	if len(ss.Tags) != 1 || ss.Tags[0] != "infra" || ss.Note != "migrating the db" {
+		t.Fatalf("expected session tags and note kept, got %#v", ss)
+	}
+	if len(ss.Panes) != 1 || ss.Panes[0].Tags != nil {
+		t.Fatalf("expected stale pane state dropped, got %#v", ss.Panes)
+	}
+}
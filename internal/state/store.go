@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,6 +19,27 @@ func NewStoreFile(path string) *StoreFile {
 
 func (s *StoreFile) Path() string { return s.path }
 
+// Lock takes an exclusive lock on a file next to the state file, blocking
+// until it is free, for read-modify-write cycles that must not interleave
+// with another process. The returned function releases it.
+func (s *StoreFile) Lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
+
 func (s *StoreFile) Load() (*Store, error) {
 	data, err := os.ReadFile(s.path)
 	if err != nil {
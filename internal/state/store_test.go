@@ -0,0 +1,42 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreFileLock(t *testing.T) {
+	sf := NewStoreFile(filepath.Join(t.TempDir(), "state", "state.yml"))
+	unlock, err := sf.Lock()
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		second, err := sf.Lock()
+		if err != nil {
+			t.Errorf("second Lock: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("expected the second lock to wait for the first")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case second := <-acquired:
+		if second != nil {
+			second()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("second lock not acquired after unlock")
+	}
+}
@@ -0,0 +1,45 @@
+package state
+
+import "strings"
+
+// ParseTags splits a list of tags separated by commas or spaces. A leading
+// "#" is dropped, so "#backend" and "backend" are the same tag.
+func ParseTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	return AddTags(nil, fields...)
+}
+
+// AddTags appends tags that aren't already present, in order.
+func AddTags(tags []string, add ...string) []string {
+	for _, tag := range add {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || hasTag(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// RemoveTags returns tags without the ones in remove.
+func RemoveTags(tags []string, remove ...string) []string {
+	drop := AddTags(nil, remove...)
+	var kept []string
+	for _, tag := range tags {
+		if !hasTag(drop, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
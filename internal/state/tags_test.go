@@ -0,0 +1,59 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" #backend, auth  backend,,urgent ")
+	want := []string{"backend", "auth", "urgent"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAddRemoveTags(t *testing.T) {
+	tags := AddTags([]string{"backend"}, "auth", "#backend", " ")
+	if want := []string{"backend", "auth"}; !reflect.DeepEqual(tags, want) {
+		t.Fatalf("expected %v, got %v", want, tags)
+	}
+	tags = RemoveTags(tags, "#backend", "missing")
+	if want := []string{"auth"}; !reflect.DeepEqual(tags, want) {
+		t.Fatalf("expected %v, got %v", want, tags)
+	}
+}
+
+func TestReconcileKeepsMetadata(t *testing.T) {
+	created := time.Now()
+	current := NewStore()
+	current.Sessions["repo"] = &SessionState{
+		Path:      "/repo",
+		CreatedAt: created,
+		Tags:      []string{"backend"},
+		Note:      "release prep",
+		Panes: []*PaneState{
+			{TmuxID: "%1", Type: "claude", Title: "claude-1", Tags: []string{"auth"}, Note: "fixing login"},
+		},
+	}
+
+	out := Reconcile(ReconcileInput{
+		CurrentState: current,
+		TmuxSessions: []domain.Session{{
+			Name:      "repo",
+			CreatedAt: created,
+			Panes:     []domain.Pane{{ID: "%1", Title: "claude-1"}},
+		}},
+	})
+
+	s := out.UpdatedState.Sessions["repo"]
+	if s == nil || s.Note != "release prep" || !reflect.DeepEqual(s.Tags, []string{"backend"}) {
+		t.Fatalf("expected session metadata to be kept, got %#v", s)
+	}
+	if p := s.Panes[0]; p.Note != "fixing login" || !reflect.DeepEqual(p.Tags, []string{"auth"}) {
+		t.Fatalf("expected pane metadata to be kept, got %#v", p)
+	}
+}
@@ -0,0 +1,102 @@
+package state
+
+import "time"
+
+// TaskStatus is where a queued task is in its life.
+type TaskStatus string
+
+const (
+	TaskQueued  TaskStatus = "queued"
+	TaskRunning TaskStatus = "running"
+	TaskDone    TaskStatus = "done"
+	// TaskFailed means the pane running the task exited or went away.
+	TaskFailed TaskStatus = "failed"
+)
+
+// Task is a prompt waiting for, or handed to, an agent pane in a session.
+type Task struct {
+	ID      int        `yaml:"id"`
+	Session string     `yaml:"session"`
+	Prompt  string     `yaml:"prompt"`
+	Source  string     `yaml:"source,omitempty"` // file the prompt was read from
+	Status  TaskStatus `yaml:"status"`
+	AddedAt time.Time  `yaml:"added_at"`
+	// PaneID and Pane (its title) record which pane took the task.
+	PaneID     string     `yaml:"pane_id,omitempty"`
+	Pane       string     `yaml:"pane,omitempty"`
+	StartedAt  *time.Time `yaml:"started_at,omitempty"`
+	FinishedAt *time.Time `yaml:"finished_at,omitempty"`
+}
+
+// AddTask appends a queued task for session and returns it.
+func (s *Store) AddTask(session, prompt, source string, now time.Time) *Task {
+	// Stores written before NextTaskID existed only have their tasks to go by.
+	id := max(s.NextTaskID, 1)
+	for _, t := range s.Tasks {
+		if t.ID >= id {
+			id = t.ID + 1
+		}
+	}
+	s.NextTaskID = id + 1
+	task := &Task{
+		ID:      id,
+		Session: session,
+		Prompt:  prompt,
+		Source:  source,
+		Status:  TaskQueued,
+		AddedAt: now,
+	}
+	s.Tasks = append(s.Tasks, task)
+	return task
+}
+
+// NextTask returns the oldest queued task for session, or nil.
+func (s *Store) NextTask(session string) *Task {
+	for _, t := range s.Tasks {
+		if t.Session == session && t.Status == TaskQueued {
+			return t
+		}
+	}
+	return nil
+}
+
+// FindTask returns the task with id, or nil.
+func (s *Store) FindTask(id int) *Task {
+	for _, t := range s.Tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// Start marks the task as taken by a pane.
+func (t *Task) Start(paneID, pane string, at time.Time) {
+	t.Status = TaskRunning
+	t.PaneID = paneID
+	t.Pane = pane
+	t.StartedAt = &at
+}
+
+// Finish marks the task done, or failed.
+func (t *Task) Finish(status TaskStatus, at time.Time) {
+	t.Status = status
+	t.FinishedAt = &at
+}
+
+// ClearFinished removes done and failed tasks of session, or of every
+// session when it is empty, and returns how many were removed.
+func (s *Store) ClearFinished(session string) int {
+	kept := s.Tasks[:0]
+	removed := 0
+	for _, t := range s.Tasks {
+		finished := t.Status == TaskDone || t.Status == TaskFailed
+		if finished && (session == "" || t.Session == session) {
+			removed++
+			continue
+		}
+		kept = append(kept, t)
+	}
+	s.Tasks = kept
+	return removed
+}
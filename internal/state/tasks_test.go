@@ -0,0 +1,73 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+func TestTaskQueueOrder(t *testing.T) {
+	st := NewStore()
+	now := time.Now()
+	first := st.AddTask("repo", "one", "", now)
+	st.AddTask("other", "elsewhere", "", now)
+	second := st.AddTask("repo", "two", "tasks/two.md", now)
+
+	if first.ID != 1 || second.ID != 3 {
+		t.Fatalf("expected ids 1 and 3, got %d and %d", first.ID, second.ID)
+	}
+	if got := st.NextTask("repo"); got != first {
+		t.Fatalf("expected first task next, got %#v", got)
+	}
+
+	first.Start("%1", "claude-1", now)
+	if got := st.NextTask("repo"); got != second {
+		t.Fatalf("expected second task once the first is running, got %#v", got)
+	}
+
+	first.Finish(TaskDone, now)
+	if removed := st.ClearFinished("repo"); removed != 1 {
+		t.Fatalf("expected 1 finished task removed, got %d", removed)
+	}
+	if len(st.Tasks) != 2 || st.FindTask(1) != nil {
+		t.Fatalf("expected only the finished task to be removed, got %#v", st.Tasks)
+	}
+	if next := st.AddTask("repo", "three", "", now); next.ID != 4 {
+		t.Fatalf("expected ids to keep increasing, got %d", next.ID)
+	}
+}
+
+func TestTaskIDsNotReusedAfterClear(t *testing.T) {
+	st := NewStore()
+	now := time.Now()
+	for _, prompt := range []string{"one", "two"} {
+		st.AddTask("repo", prompt, "", now).Finish(TaskDone, now)
+	}
+	if removed := st.ClearFinished(""); removed != 2 {
+		t.Fatalf("expected 2 finished tasks removed, got %d", removed)
+	}
+	if next := st.AddTask("repo", "three", "", now); next.ID != 3 {
+		t.Fatalf("expected id 3 after clearing, got %d", next.ID)
+	}
+
+	// Stores saved before the counter existed continue after their tasks.
+	legacy := NewStore()
+	legacy.Tasks = []*Task{{ID: 7, Session: "repo", Status: TaskQueued}}
+	if next := legacy.AddTask("repo", "eight", "", now); next.ID != 8 {
+		t.Fatalf("expected id 8, got %d", next.ID)
+	}
+}
+
+func TestReconcileKeepsTasks(t *testing.T) {
+	st := NewStore()
+	st.AddTask("gone", "prompt", "", time.Now())
+
+	output := Reconcile(ReconcileInput{CurrentState: st, TmuxSessions: []domain.Session{{Name: "repo"}}})
+	if len(output.UpdatedState.Tasks) != 1 {
+		t.Fatalf("expected tasks to be kept, got %#v", output.UpdatedState.Tasks)
+	}
+	if output.UpdatedState.NextTaskID != 2 {
+		t.Fatalf("expected the task counter to be kept, got %d", output.UpdatedState.NextTaskID)
+	}
+}
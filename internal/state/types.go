@@ -6,20 +6,95 @@ type Store struct {
 	Version  int                      `yaml:"version"`
 	ServerID string                   `yaml:"server_id,omitempty"`
 	Sessions map[string]*SessionState `yaml:"sessions"`
+	// Pinned lists favorite sessions by name, kept even while they don't exist.
+	Pinned []string `yaml:"pinned,omitempty"`
+	// Tasks is the work queue, in the order tasks were added.
+	Tasks []*Task `yaml:"tasks,omitempty"`
+	// NextTaskID is the ID the next task gets, so IDs aren't reused after
+	// finished tasks are cleared.
+	NextTaskID int `yaml:"next_task_id,omitempty"`
 }
 
 type SessionState struct {
 	Path      string       `yaml:"path"`
+	Profile   string       `yaml:"profile,omitempty"`
 	CreatedAt time.Time    `yaml:"created_at"`
+	Tags      []string     `yaml:"tags,omitempty"`
+	Note      string       `yaml:"note,omitempty"`
 	Panes     []*PaneState `yaml:"panes"`
 }
 
 type PaneState struct {
-	TmuxID    string     `yaml:"tmux_id"`
-	Type      string     `yaml:"type"`
-	Title     string     `yaml:"title"`
-	CreatedAt time.Time  `yaml:"created_at"`
-	RenamedAt *time.Time `yaml:"renamed_at,omitempty"`
+	TmuxID        string         `yaml:"tmux_id"`
+	Type          string         `yaml:"type"`
+	Title         string         `yaml:"title"`
+	Path          string         `yaml:"path,omitempty"`
+	Command       string         `yaml:"command,omitempty"`
+	CreatedAt     time.Time      `yaml:"created_at"`
+	RenamedAt     *time.Time     `yaml:"renamed_at,omitempty"`
+	Restarts      int            `yaml:"restarts,omitempty"`
+	LastRestartAt *time.Time     `yaml:"last_restart_at,omitempty"`
+	RestartPolicy *RestartPolicy `yaml:"restart_policy,omitempty"`
+	RestartLog    []RestartEvent `yaml:"restart_log,omitempty"`
+	// RetryStreak counts the supervisor's restarts since the pane last
+	// stayed up for a while or was restarted by hand.
+	RetryStreak  int      `yaml:"retry_streak,omitempty"`
+	InputHistory []string `yaml:"input_history,omitempty"`
+	Tags         []string `yaml:"tags,omitempty"`
+	// Note is free text, typically what the agent is working on.
+	Note string `yaml:"note,omitempty"`
+	// SpecCommand is the command as written in the config, before
+	// placeholders were expanded, when it differs from Command.
+	SpecCommand string `yaml:"spec_command,omitempty"`
+}
+
+// RestartPolicy is the resolved policy the supervisor applies to a pane.
+type RestartPolicy struct {
+	Restart     string `yaml:"restart"`
+	MaxRestarts int    `yaml:"max_restarts"`
+	Backoff     string `yaml:"backoff"`
+}
+
+type RestartEvent struct {
+	At     time.Time `yaml:"at"`
+	Reason string    `yaml:"reason"`
+	// Supervised marks restarts done by the supervisor rather than by hand.
+	Supervised bool `yaml:"supervised,omitempty"`
+}
+
+// MaxRestartLog bounds the number of restart events kept per pane. It is the
+// highest max_restarts the config accepts, so the log covers a full budget.
+const MaxRestartLog = 20
+
+// MaxInputHistory bounds the number of sent messages kept per pane.
+const MaxInputHistory = 50
+
+// RecordInput appends text to the pane's input history, skipping repeats of
+// the most recent entry.
+func (p *PaneState) RecordInput(text string) {
+	if n := len(p.InputHistory); n > 0 && p.InputHistory[n-1] == text {
+		return
+	}
+	p.InputHistory = append(p.InputHistory, text)
+	if len(p.InputHistory) > MaxInputHistory {
+		p.InputHistory = p.InputHistory[len(p.InputHistory)-MaxInputHistory:]
+	}
+}
+
+// RecordRestart bumps the restart counter and appends to the restart log.
+// Supervised restarts extend the retry streak; manual ones end it.
+func (p *PaneState) RecordRestart(at time.Time, reason string, supervised bool) {
+	p.Restarts++
+	if supervised {
+		p.RetryStreak++
+	} else {
+		p.RetryStreak = 0
+	}
+	p.LastRestartAt = &at
+	p.RestartLog = append(p.RestartLog, RestartEvent{At: at, Reason: reason, Supervised: supervised})
+	if len(p.RestartLog) > MaxRestartLog {
+		p.RestartLog = p.RestartLog[len(p.RestartLog)-MaxRestartLog:]
+	}
 }
 
 func NewStore() *Store {
@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 )
@@ -99,18 +101,56 @@ func (c *Client) SessionPath(name string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
-func (c *Client) NewSession(name, cwd string) error {
-	return c.run("new-session", "-d", "-s", name, "-c", cwd)
+func (c *Client) NewSession(name, cwd string, env map[string]string) error {
+	args := []string{"new-session", "-d", "-s", name, "-c", cwd}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+env[k])
+	}
+	return c.run(args...)
 }
 
 func (c *Client) AttachSession(name string) error {
 	return c.runInteractive("attach-session", "-t", name)
 }
 
+// AttachCommand returns an unstarted attach-session command for callers that
+// hand the terminal over themselves.
+func (c *Client) AttachCommand(name string) *exec.Cmd {
+	fullArgs := append([]string{}, c.baseArgs...)
+	fullArgs = append(fullArgs, "attach-session", "-t", name)
+	return exec.Command(c.tmuxPath, fullArgs...)
+}
+
 func (c *Client) SwitchClient(name string) error {
 	return c.runInteractive("switch-client", "-t", name)
 }
 
+// SelectPane makes the pane the active pane of its window and the window the
+// current window of its session.
+func (c *Client) SelectPane(paneID string) error {
+	if err := c.run("select-window", "-t", paneID); err != nil {
+		return err
+	}
+	return c.run("select-pane", "-t", paneID)
+}
+
+// ZoomPane zooms the pane unless its window is already zoomed.
+func (c *Client) ZoomPane(paneID string) error {
+	out, err := c.runOutput("display-message", "-p", "-t", paneID, "#{window_zoomed_flag}")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(out) == "1" {
+		return nil
+	}
+	return c.run("resize-pane", "-Z", "-t", paneID)
+}
+
 func (c *Client) KillSession(name string) error {
 	return c.run("kill-session", "-t", name)
 }
@@ -212,6 +252,28 @@ func (c *Client) KillPane(paneID string) error {
 	return c.run("kill-pane", "-t", paneID)
 }
 
+func (c *Client) PaneOption(paneID, option string) (string, error) {
+	out, err := c.runOutput("display-message", "-p", "-t", paneID, "#{"+option+"}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (c *Client) UnsetPaneOption(paneID, option string) error {
+	return c.run("set-option", "-p", "-u", "-t", paneID, option)
+}
+
+// RespawnPane kills whatever runs in the pane and starts a fresh shell in
+// place, keeping the pane ID.
+func (c *Client) RespawnPane(paneID, cwd string) error {
+	args := []string{"respawn-pane", "-k", "-t", paneID}
+	if strings.TrimSpace(cwd) != "" {
+		args = append(args, "-c", cwd)
+	}
+	return c.run(args...)
+}
+
 func (c *Client) CapturePaneContent(paneID string) (string, error) {
 	out, err := c.runOutput("capture-pane", "-t", paneID, "-p")
 	if err != nil {
@@ -220,6 +282,12 @@ func (c *Client) CapturePaneContent(paneID string) (string, error) {
 	return out, nil
 }
 
+// CapturePaneHistory returns the pane's full scrollback with ANSI escape
+// sequences preserved.
+func (c *Client) CapturePaneHistory(paneID string) (string, error) {
+	return c.runOutput("capture-pane", "-t", paneID, "-p", "-e", "-S", "-")
+}
+
 func (c *Client) SendKeysLiteral(paneID, text string) error {
 	return c.run("send-keys", "-t", paneID, "-l", text)
 }
@@ -228,6 +296,32 @@ func (c *Client) SendEnter(paneID string) error {
 	return c.run("send-keys", "-t", paneID, "Enter")
 }
 
+// PasteText pastes text into a pane as a bracketed paste, so multi-line text
+// arrives as one input instead of being submitted line by line.
+func (c *Client) PasteText(paneID, text string) error {
+	buffer := "agentpane-" + strings.TrimPrefix(paneID, "%")
+	if _, err := c.runInput(strings.NewReader(text), "load-buffer", "-b", buffer, "-"); err != nil {
+		return err
+	}
+	return c.run("paste-buffer", "-p", "-d", "-b", buffer, "-t", paneID)
+}
+
+// SendKeys sends tmux key names such as "Escape" or "C-c".
+func (c *Client) SendKeys(paneID string, keys ...string) error {
+	args := append([]string{"send-keys", "-t", paneID}, keys...)
+	return c.run(args...)
+}
+
+// WindowLayout returns the layout string of a session's first window, which
+// SelectLayout accepts to restore the same pane arrangement.
+func (c *Client) WindowLayout(session string) (string, error) {
+	out, err := c.runOutput("display-message", "-p", "-t", session+":0", "#{window_layout}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
 func (c *Client) SelectLayout(session, layout string) error {
 	return c.run("select-layout", "-t", session+":0", layout)
 }
@@ -259,6 +353,10 @@ func (c *Client) SetEnv(name, value string) error {
 	return c.run("set-environment", "-g", name, value)
 }
 
+func (c *Client) SetSessionEnv(session, name, value string) error {
+	return c.run("set-environment", "-t", session, name, value)
+}
+
 func (c *Client) SupportsPopup() (bool, error) {
 	out, err := c.runOutput("list-commands")
 	if err != nil {
@@ -304,6 +402,11 @@ func (c *Client) runInteractive(args ...string) error {
 }
 
 func (c *Client) runOutput(args ...string) (string, error) {
+	return c.runInput(nil, args...)
+}
+
+// runInput is runOutput with stdin, for commands that read from "-".
+func (c *Client) runInput(stdin io.Reader, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -311,6 +414,7 @@ func (c *Client) runOutput(args ...string) (string, error) {
 	fullArgs = append(fullArgs, args...)
 
 	cmd := exec.CommandContext(ctx, c.tmuxPath, fullArgs...)
+	cmd.Stdin = stdin
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -6,7 +6,8 @@ const (
 	SessionFormat = "#{session_name}" + Delim +
 		"#{session_path}" + Delim +
 		"#{session_created}" + Delim +
-		"#{session_attached}"
+		"#{session_attached}" + Delim +
+		"#{session_activity}"
 
 	PaneFormat = "#{pane_id}" + Delim +
 		"#{pane_index}" + Delim +
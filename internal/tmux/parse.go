@@ -26,15 +26,20 @@ func parseTable(output string, expectedFields int) [][]string {
 }
 
 func ParseSessions(output string) ([]RawSession, error) {
+	// session_activity was added later; accept rows without it.
 	rows := parseTable(output, 4)
 	out := make([]RawSession, 0, len(rows))
 	for _, r := range rows {
-		out = append(out, RawSession{
+		s := RawSession{
 			Name:     r[0],
 			Path:     r[1],
 			Created:  r[2],
 			Attached: r[3],
-		})
+		}
+		if len(r) > 4 {
+			s.Activity = r[4]
+		}
+		out = append(out, s)
 	}
 	return out, nil
 }
@@ -29,3 +29,14 @@ func TestParseSessionsEscapedDelimiter(t *testing.T) {
 		t.Fatalf("unexpected session: %#v", sessions[0])
 	}
 }
+
+func TestParseSessionsActivity(t *testing.T) {
+	output := "repo" + Delim + "/tmp/repo" + Delim + "1700000000" + Delim + "0" + Delim + "1700000500\n"
+	sessions, err := ParseSessions(output)
+	if err != nil {
+		t.Fatalf("ParseSessions error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Activity != "1700000500" {
+		t.Fatalf("unexpected sessions: %#v", sessions)
+	}
+}
@@ -5,6 +5,7 @@ type RawSession struct {
 	Path     string
 	Created  string
 	Attached string
+	Activity string
 }
 
 type RawPane struct {
@@ -1,60 +1,183 @@
 package common
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+)
+
+// Palette holds the colors the styles below are built from.
+type Palette struct {
+	Primary   lipgloss.TerminalColor
+	Secondary lipgloss.TerminalColor
+	Success   lipgloss.TerminalColor
+	Warning   lipgloss.TerminalColor
+	Error     lipgloss.TerminalColor
+	Highlight lipgloss.TerminalColor // selected rows and the active session tab
+	Accent    lipgloss.TerminalColor // selected completion in dialogs
+	Border    lipgloss.TerminalColor
+}
+
+var (
+	DarkPalette = Palette{
+		Primary:   lipgloss.Color("33"),  // blue
+		Secondary: lipgloss.Color("245"), // gray
+		Success:   lipgloss.Color("70"),  // green
+		Warning:   lipgloss.Color("214"),
+		Error:     lipgloss.Color("203"),
+		Highlight: lipgloss.Color("229"),
+		Accent:    lipgloss.Color("212"),
+		Border:    lipgloss.Color("240"),
+	}
+
+	LightPalette = Palette{
+		Primary:   lipgloss.Color("25"),
+		Secondary: lipgloss.Color("242"),
+		Success:   lipgloss.Color("28"),
+		Warning:   lipgloss.Color("130"),
+		Error:     lipgloss.Color("160"),
+		Highlight: lipgloss.Color("16"),
+		Accent:    lipgloss.Color("125"),
+		Border:    lipgloss.Color("250"),
+	}
+
+	NoColorPalette = Palette{
+		Primary:   lipgloss.NoColor{},
+		Secondary: lipgloss.NoColor{},
+		Success:   lipgloss.NoColor{},
+		Warning:   lipgloss.NoColor{},
+		Error:     lipgloss.NoColor{},
+		Highlight: lipgloss.NoColor{},
+		Accent:    lipgloss.NoColor{},
+		Border:    lipgloss.NoColor{},
+	}
+)
 
 var (
-	ColorPrimary   = lipgloss.Color("33")  // blue
-	ColorSecondary = lipgloss.Color("245") // gray
-	ColorSuccess   = lipgloss.Color("70")  // green
-	ColorWarning   = lipgloss.Color("214")
+	ColorPrimary   lipgloss.TerminalColor
+	ColorSecondary lipgloss.TerminalColor
+	ColorSuccess   lipgloss.TerminalColor
+	ColorWarning   lipgloss.TerminalColor
+	ColorError     lipgloss.TerminalColor
+	ColorHighlight lipgloss.TerminalColor
+	ColorAccent    lipgloss.TerminalColor
+	ColorBorder    lipgloss.TerminalColor
+
+	PanelStyle            lipgloss.Style
+	TitleStyle            lipgloss.Style
+	NormalStyle           lipgloss.Style
+	SelectedStyle         lipgloss.Style
+	DimSelectedStyle      lipgloss.Style
+	TabStyle              lipgloss.Style
+	ActiveTabStyle        lipgloss.Style
+	SessionTabStyle       lipgloss.Style
+	ActiveSessionTabStyle lipgloss.Style
+	CurrentSessionMarker  lipgloss.Style
+	FooterStyle           lipgloss.Style
+	StatusStyle           lipgloss.Style
+	ErrorStyle            lipgloss.Style
+)
+
+func init() {
+	applyPalette(DarkPalette)
+}
+
+// ApplyTheme rebuilds the styles from the configured theme and color
+// overrides. NO_COLOR in the environment always selects the none theme.
+func ApplyTheme(ui config.UIConfig) {
+	theme := ui.Theme
+	if os.Getenv("NO_COLOR") != "" {
+		theme = config.ThemeNone
+	}
+
+	var p Palette
+	switch theme {
+	case config.ThemeLight:
+		p = LightPalette
+	case config.ThemeNone:
+		applyPalette(NoColorPalette)
+		return
+	default:
+		p = DarkPalette
+	}
+
+	overrides := map[string]*lipgloss.TerminalColor{
+		"primary":   &p.Primary,
+		"secondary": &p.Secondary,
+		"success":   &p.Success,
+		"warning":   &p.Warning,
+		"error":     &p.Error,
+		"highlight": &p.Highlight,
+		"accent":    &p.Accent,
+		"border":    &p.Border,
+	}
+	for role, color := range ui.Colors {
+		if c, ok := overrides[role]; ok {
+			*c = lipgloss.Color(color)
+		}
+	}
+	applyPalette(p)
+}
+
+func applyPalette(p Palette) {
+	ColorPrimary = p.Primary
+	ColorSecondary = p.Secondary
+	ColorSuccess = p.Success
+	ColorWarning = p.Warning
+	ColorError = p.Error
+	ColorHighlight = p.Highlight
+	ColorAccent = p.Accent
+	ColorBorder = p.Border
 
 	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorSecondary).
-			Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorSecondary).
+		Padding(1)
 
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorPrimary)
 
 	NormalStyle = lipgloss.NewStyle()
 
 	SelectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("229"))
+		Bold(true).
+		Foreground(ColorHighlight)
 
 	DimSelectedStyle = lipgloss.NewStyle().
-				Foreground(ColorSecondary)
+		Foreground(ColorSecondary)
 
 	TabStyle = lipgloss.NewStyle().
-			Padding(0, 2)
+		Padding(0, 2)
 
-	ActiveTabStyle = TabStyle.Copy().
-			Bold(true).
-			Foreground(ColorPrimary)
+	ActiveTabStyle = TabStyle.
+		Bold(true).
+		Foreground(ColorPrimary)
 
 	SessionTabStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Foreground(ColorSecondary)
+		Padding(0, 1).
+		Foreground(ColorSecondary)
 
 	ActiveSessionTabStyle = lipgloss.NewStyle().
-				Padding(0, 1).
-				Bold(true).
-				Foreground(lipgloss.Color("229")).
-				Background(ColorPrimary)
+		Padding(0, 1).
+		Bold(true).
+		Foreground(ColorHighlight).
+		Background(ColorPrimary)
 
 	CurrentSessionMarker = lipgloss.NewStyle().
-				Foreground(ColorSuccess)
+		Foreground(ColorSuccess)
 
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			Padding(1, 0)
+		Foreground(ColorSecondary).
+		Padding(1, 0)
 
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess).
-			Padding(0, 0)
+		Foreground(ColorSuccess).
+		Padding(0, 0)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("203")).
-			Padding(0, 0)
-)
+		Foreground(ColorError).
+		Padding(0, 0)
+}
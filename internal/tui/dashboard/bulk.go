@@ -0,0 +1,327 @@
+package dashboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/dialogs"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+// markedPane is a marked pane together with the session it lives in
+type markedPane struct {
+	Session string
+	Pane    domain.Pane
+}
+
+// bulkFailure records a target a bulk operation failed on
+type bulkFailure struct {
+	key  string // pane ID or session name
+	name string
+	err  error
+}
+
+func (m Model) markCount() int {
+	return len(m.markedPanes) + len(m.markedSessions)
+}
+
+func (m Model) isMarked(item TreeItem) bool {
+	switch item.Type {
+	case ItemSession:
+		return m.markedSessions[item.Session]
+	case ItemPane:
+		return m.markedPanes[item.Pane.ID]
+	}
+	return false
+}
+
+// toggleMark marks or unmarks the item under the cursor and moves down.
+func (m Model) toggleMark() (tea.Model, tea.Cmd) {
+	tree := m.buildTree()
+	if m.tab != TabSessions || m.treeIndex >= len(tree) {
+		return m, nil
+	}
+	item := tree[m.treeIndex]
+	if item.Type == ItemGroup {
+		return m, nil
+	}
+	marks := m.markedPanes
+	key := ""
+	if item.Type == ItemSession {
+		marks, key = m.markedSessions, item.Session
+	} else {
+		key = item.Pane.ID
+	}
+	if marks[key] {
+		delete(marks, key)
+	} else {
+		marks[key] = true
+	}
+	if m.treeIndex < len(tree)-1 {
+		m.treeIndex++
+	}
+	return m, nil
+}
+
+// pruneMarks drops marks on panes and sessions that no longer exist, so the
+// marked count and bulk actions only cover live items.
+func (m Model) pruneMarks() Model {
+	if m.markCount() == 0 {
+		return m
+	}
+	panes := make(map[string]bool)
+	sessions := make(map[string]bool)
+	for _, s := range m.snapshot.Sessions {
+		if m.markedSessions[s.Name] {
+			sessions[s.Name] = true
+		}
+		for _, p := range s.Panes {
+			if m.markedPanes[p.ID] {
+				panes[p.ID] = true
+			}
+		}
+	}
+	m.markedPanes = panes
+	m.markedSessions = sessions
+	return m
+}
+
+func (m Model) clearMarks() Model {
+	m.markedPanes = make(map[string]bool)
+	m.markedSessions = make(map[string]bool)
+	return m
+}
+
+// markedPaneList returns marked panes that still exist, in tree order.
+func (m Model) markedPaneList() []markedPane {
+	var out []markedPane
+	for _, s := range m.snapshot.Sessions {
+		for _, p := range s.Panes {
+			if m.markedPanes[p.ID] {
+				out = append(out, markedPane{Session: s.Name, Pane: p})
+			}
+		}
+	}
+	return out
+}
+
+// markedSessionList returns marked sessions that still exist, in tree order.
+func (m Model) markedSessionList() []string {
+	var out []string
+	for _, s := range m.snapshot.Sessions {
+		if m.markedSessions[s.Name] {
+			out = append(out, s.Name)
+		}
+	}
+	return out
+}
+
+// handleBulkKey handles keys that act on all marked items. It reports false
+// for keys that keep their single-item meaning, and for pane actions when
+// only sessions are marked (or the reverse), so they apply to the cursor.
+func (m Model) handleBulkKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	panes := m.markedPaneList()
+	sessions := m.markedSessionList()
+
+	key, km := msg.String(), m.keys
+	switch {
+	case km.Matches(key, keys.ClearMarks):
+		m = m.clearMarks()
+		m.statusMsg = "marks cleared"
+		return m, nil, true
+	case km.Matches(key, keys.ClosePane):
+		if len(panes) == 0 {
+			return m, nil, false
+		}
+		m.confirmAction = confirmBulkClose
+		m.dialog = dialogs.NewConfirm(
+			fmt.Sprintf("Close %s?", plural(len(panes), "pane")),
+			"This will kill the marked panes and any running processes.",
+		)
+		return m, nil, true
+	case km.Matches(key, keys.Restart):
+		if len(panes) == 0 {
+			return m, nil, false
+		}
+		m.confirmAction = confirmBulkRestart
+		m.dialog = dialogs.NewConfirm(
+			fmt.Sprintf("Restart %s?", plural(len(panes), "pane")),
+			"Running processes in the marked panes will be killed and relaunched.",
+		)
+		return m, nil, true
+	case km.Matches(key, keys.KillSession):
+		if len(sessions) == 0 {
+			return m, nil, false
+		}
+		m.confirmAction = confirmBulkKill
+		m.dialog = dialogs.NewConfirm(
+			fmt.Sprintf("Kill %s?", plural(len(sessions), "session")),
+			fmt.Sprintf("This will kill %s and all their panes.", strings.Join(sessions, ", ")),
+		)
+		return m, nil, true
+	case km.Matches(key, keys.Rename):
+		if len(panes) == 0 {
+			return m, nil, false
+		}
+		m.bulkRename = true
+		m.dialog = dialogs.NewRenamePattern(len(panes))
+		return m, nil, true
+	case km.Matches(key, keys.Send):
+		if len(panes) == 0 {
+			return m, nil, false
+		}
+		m.sendActive = true
+		m.sendBroadcast = true
+		m.sendTitle = plural(len(panes), "pane")
+		m.sendHistory = nil
+		m.sendHistoryIndex = 0
+		m.sendInput.SetValue("")
+		m.sendInput.Focus()
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// confirmBulkRenameDialog asks to apply a rename pattern, previewing the
+// first new title.
+func (m Model) confirmBulkRenameDialog(pattern string) (tea.Model, tea.Cmd) {
+	panes := m.markedPaneList()
+	if len(panes) == 0 {
+		m.errorMsg = "no panes marked"
+		return m, nil
+	}
+	m.bulkText = pattern
+	m.confirmAction = confirmBulkRename
+	m.dialog = dialogs.NewConfirm(
+		fmt.Sprintf("Rename %s?", plural(len(panes), "pane")),
+		fmt.Sprintf("'%s' becomes '%s'.", panes[0].Pane.Title, renameFromPattern(pattern, panes[0].Pane.Title, 1)),
+	)
+	return m, nil
+}
+
+// confirmBroadcastDialog asks before sending text to every marked pane.
+func (m Model) confirmBroadcastDialog(text string) (tea.Model, tea.Cmd) {
+	panes := m.markedPaneList()
+	m.bulkText = text
+	m.confirmAction = confirmBulkBroadcast
+	m.dialog = dialogs.NewConfirm(
+		fmt.Sprintf("Send to %s?", plural(len(panes), "pane")),
+		fmt.Sprintf("%q will be typed into each marked pane followed by Enter.", text),
+	)
+	return m, nil
+}
+
+// runBulk performs a confirmed bulk action. Marks are kept only on the items
+// that failed so the action can be retried.
+func (m Model) runBulk(action confirmAction) (tea.Model, tea.Cmd) {
+	var (
+		failures []bulkFailure
+		total    int
+		verb     string
+		noun     = "pane"
+	)
+
+	switch action {
+	case confirmBulkKill:
+		noun, verb = "session", "killed"
+		sessions := m.markedSessionList()
+		total = len(sessions)
+		for _, name := range sessions {
+			if err := m.app.KillSession(name); err != nil {
+				failures = append(failures, bulkFailure{key: name, name: name, err: err})
+			}
+		}
+	default:
+		panes := m.markedPaneList()
+		total = len(panes)
+		for i, mp := range panes {
+			var err error
+			switch action {
+			case confirmBulkClose:
+				verb = "closed"
+				err = m.app.ClosePane(mp.Pane.ID)
+			case confirmBulkRestart:
+				verb = "restarted"
+				_, err = m.app.RestartPane(app.RestartOptions{Session: mp.Session, PaneID: mp.Pane.ID})
+			case confirmBulkRename:
+				verb = "renamed"
+				_, err = m.app.Rename(app.RenameOptions{
+					Title:   renameFromPattern(m.bulkText, mp.Pane.Title, i+1),
+					Session: mp.Session,
+					PaneID:  mp.Pane.ID,
+				})
+			case confirmBulkBroadcast:
+				verb = "sent to"
+				err = m.app.SendInput(mp.Session, mp.Pane.ID, m.bulkText)
+			}
+			if err != nil {
+				failures = append(failures, bulkFailure{key: mp.Pane.ID, name: mp.Pane.Title, err: err})
+			}
+		}
+	}
+
+	m.bulkText = ""
+	m.confirmAction = confirmNone
+	failedMarks := make(map[string]bool)
+	for _, f := range failures {
+		failedMarks[f.key] = true
+	}
+	m = m.clearMarks()
+	if noun == "session" {
+		m.markedSessions = failedMarks
+		m.treeIndex = 0
+	} else {
+		m.markedPanes = failedMarks
+	}
+
+	if len(failures) == 0 {
+		m.errorMsg = ""
+		m.statusMsg = fmt.Sprintf("%s %s", verb, plural(total, noun))
+		return m, m.refreshSnapshot()
+	}
+	parts := make([]string, len(failures))
+	for i, f := range failures {
+		parts[i] = fmt.Sprintf("%s (%v)", f.name, f.err)
+	}
+	m.statusMsg = ""
+	m.errorMsg = fmt.Sprintf("%s %d of %s; failed: %s", verb, total-len(failures), plural(total, noun), strings.Join(parts, "; "))
+	return m, m.refreshSnapshot()
+}
+
+// broadcastKeys sends control keys to every marked pane.
+func (m Model) broadcastKeys(label string, keys ...string) (tea.Model, tea.Cmd) {
+	var failed []string
+	panes := m.markedPaneList()
+	for _, mp := range panes {
+		if err := m.app.SendKeys(mp.Pane.ID, keys...); err != nil {
+			failed = append(failed, mp.Pane.Title)
+		}
+	}
+	if len(failed) > 0 {
+		m.errorMsg = fmt.Sprintf("sent %s to %d of %s; failed: %s", label, len(panes)-len(failed), plural(len(panes), "pane"), strings.Join(failed, ", "))
+		return m, m.refreshSnapshot()
+	}
+	m.errorMsg = ""
+	m.statusMsg = fmt.Sprintf("sent %s to %s", label, plural(len(panes), "pane"))
+	return m, m.refreshSnapshot()
+}
+
+// renameFromPattern builds a title from a bulk rename pattern. {{title}} is
+// the pane's current title and {{index}} its 1-based position among the
+// marked panes.
+func renameFromPattern(pattern, title string, index int) string {
+	r := strings.NewReplacer("{{title}}", title, "{{index}}", strconv.Itoa(index))
+	return strings.TrimSpace(r.Replace(pattern))
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,87 @@
+package dashboard
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+func bulkTestModel() Model {
+	m := NewModel(nil, keys.Default())
+	m.snapshot = domain.Snapshot{Sessions: []domain.Session{
+		{Name: "api", Panes: []domain.Pane{
+			{ID: "%1", Title: "claude-1", Type: domain.PaneClaude},
+			{ID: "%2", Title: "shell-1", Type: domain.PaneShell},
+		}},
+		{Name: "web", Panes: []domain.Pane{
+			{ID: "%3", Title: "codex-1", Type: domain.PaneCodex},
+		}},
+	}}
+	return m
+}
+
+// cursorOn moves the tree cursor to the pane with the given ID.
+func cursorOn(t *testing.T, m Model, paneID string) Model {
+	t.Helper()
+	for i, item := range m.buildTree() {
+		if item.Type == ItemPane && item.Pane.ID == paneID {
+			m.treeIndex = i
+			return m
+		}
+	}
+	t.Fatalf("pane %s not in tree", paneID)
+	return m
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestPruneMarks(t *testing.T) {
+	m := bulkTestModel()
+	m.markedPanes = map[string]bool{"%1": true, "%9": true}
+	m.markedSessions = map[string]bool{"web": true, "gone": true}
+
+	updated, _ := m.Update(snapshotMsg{snapshot: m.snapshot})
+	m = updated.(Model)
+
+	if m.markCount() != 2 || !m.markedPanes["%1"] || !m.markedSessions["web"] {
+		t.Fatalf("marks after prune = panes %v sessions %v, want only %%1 and web", m.markedPanes, m.markedSessions)
+	}
+}
+
+func TestBulkKeyFallsBackToCursor(t *testing.T) {
+	m := cursorOn(t, bulkTestModel(), "%2")
+	m.markedSessions = map[string]bool{"web": true}
+
+	// Only a session is marked, so close applies to the pane under the cursor
+	updated, _ := m.Update(runeKey('d'))
+	got := updated.(Model)
+	if got.confirmAction != confirmClosePane || got.confirmPaneID != "%2" {
+		t.Fatalf("close with only sessions marked: action %v pane %q, want single close of %%2", got.confirmAction, got.confirmPaneID)
+	}
+	if got.errorMsg != "" {
+		t.Fatalf("unexpected error %q", got.errorMsg)
+	}
+
+	// With panes marked, close applies to all of them
+	m.markedPanes = map[string]bool{"%1": true, "%3": true}
+	updated, _ = m.Update(runeKey('d'))
+	if got := updated.(Model); got.confirmAction != confirmBulkClose {
+		t.Fatalf("close with panes marked: action %v, want bulk close", got.confirmAction)
+	}
+}
+
+func TestSessionKeysIgnoredOnTemplatesTab(t *testing.T) {
+	m := bulkTestModel()
+	m.tab = TabTemplates
+
+	updated, _ := m.Update(runeKey('x'))
+	got := updated.(Model)
+	if got.errorMsg != "" || got.statusMsg != "" || got.dialog != nil {
+		t.Fatalf("add_codex acted on the Templates tab: error %q status %q", got.errorMsg, got.statusMsg)
+	}
+}
@@ -0,0 +1,77 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/state"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/dialogs"
+)
+
+// editMetadata opens the tags or note dialog for the session or pane under
+// the cursor.
+func (m Model) editMetadata(field dialogs.MetadataField) (tea.Model, tea.Cmd) {
+	item := m.selectedTreeItem()
+	if item == nil || item.Type == ItemGroup {
+		return m, nil
+	}
+
+	var tags []string
+	var note string
+	if pane := item.Pane; pane != nil {
+		m.metaTarget = app.MetaTarget{Session: item.Session, PaneID: pane.ID, Title: pane.Title, Type: pane.Type}
+		tags, note = pane.Tags, pane.Note
+	} else {
+		session := m.selectedSession()
+		if session == nil {
+			return m, nil
+		}
+		m.metaTarget = app.MetaTarget{Session: session.Name}
+		tags, note = session.Tags, session.Note
+	}
+
+	if field == dialogs.FieldTags {
+		m.dialog = dialogs.NewEditTags(m.metaTarget.String(), tags)
+	} else {
+		m.dialog = dialogs.NewEditNote(m.metaTarget.String(), note)
+	}
+	return m, nil
+}
+
+func (m Model) saveMetadata(msg dialogs.MetadataResult) (tea.Model, tea.Cmd) {
+	target := m.metaTarget
+	m.metaTarget = app.MetaTarget{}
+	if msg.Cancelled || target.Session == "" {
+		return m, nil
+	}
+
+	if msg.Field == dialogs.FieldTags {
+		if _, err := m.app.EditTags(target, app.TagEdit{Add: state.ParseTags(msg.Value), Replace: true}); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("tags updated on %s", target)
+	} else {
+		if err := m.app.SetNote(target, msg.Value); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("note updated on %s", target)
+	}
+	return m, m.refreshSnapshot()
+}
+
+// metadataLabel renders tags as #tag followed by the note, for tree rows.
+func metadataLabel(tags []string, note string) string {
+	var parts []string
+	for _, tag := range tags {
+		parts = append(parts, "#"+tag)
+	}
+	if note != "" {
+		parts = append(parts, "· "+strings.ReplaceAll(note, "\n", " "))
+	}
+	return strings.Join(parts, " ")
+}
@@ -1,11 +1,14 @@
 package dashboard
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/minghinmatthewlam/agentpane/internal/app"
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
 )
 
 type Tab int
@@ -29,6 +32,13 @@ const (
 	confirmClosePane
 	confirmApplyTemplate
 	confirmKillSession
+	confirmRestartPane
+	confirmBulkClose
+	confirmBulkRestart
+	confirmBulkRename
+	confirmBulkBroadcast
+	confirmBulkKill
+	confirmDeleteTemplate
 )
 
 const (
@@ -42,17 +52,20 @@ type TreeItemType int
 const (
 	ItemSession TreeItemType = iota
 	ItemPane
+	ItemGroup
 )
 
 // TreeItem represents an item in the session/pane tree
 type TreeItem struct {
 	Type    TreeItemType
-	Session string       // session name
-	Pane    *domain.Pane // nil for session items
+	Session string       // session name, empty for group items
+	Pane    *domain.Pane // nil for session and group items
+	Group   string       // group label for group items
 }
 
 type Model struct {
-	app *app.App
+	app  *app.App
+	keys keys.Map
 
 	snapshot domain.Snapshot
 
@@ -65,12 +78,21 @@ type Model struct {
 
 	dialog tea.Model
 
+	// viewer is the full-screen pane viewer or diff review, nil when closed
+	viewer tea.Model
+
 	statusMsg string
 	errorMsg  string
 
 	tooNarrow bool
 
+	// popup is set when the dashboard runs in a tmux popup, which closes
+	// after switching sessions instead of covering the new one
+	popup bool
+
 	templates []app.TemplateSummary
+	// followTemplate moves the cursor to a template once the list reloads
+	followTemplate string
 
 	confirmAction   confirmAction
 	confirmSession  string
@@ -81,68 +103,102 @@ type Model struct {
 	renameSession string
 	renamePaneID  string
 
-	// addPaneType is set when user presses c/x/s to quick-add a pane
-	addPaneType domain.PaneType
+	// metaTarget is the session or pane whose tags or note are being edited
+	metaTarget app.MetaTarget
 
-	// attachSession is set when user wants to attach to a session after exit
-	attachSession string
+	// Session ordering and grouping, cycled with the sort/group keys
+	sortMode  sortMode
+	groupMode groupMode
+	// followSession keeps the cursor on a session across the next refresh
+	followSession string
 
-	// openSessionPath is set when user wants to open a new session at a path
-	openSessionPath string
+	// Multi-select: marked panes by ID and sessions by name
+	markedPanes    map[string]bool
+	markedSessions map[string]bool
+	// bulkRename is set while the rename dialog edits a pattern for marked panes
+	bulkRename bool
+	// bulkText is the rename pattern or broadcast message awaiting confirmation
+	bulkText string
 
 	// Session filtering
 	filterInput  textinput.Model
 	filterActive bool
 
+	// Inline input for sending text to a pane
+	sendInput        textinput.Model
+	sendActive       bool
+	sendSession      string
+	sendPaneID       string
+	sendTitle        string
+	sendHistory      []string
+	sendHistoryIndex int  // index into sendHistory while browsing, len() when not
+	sendBroadcast    bool // send box targets all marked panes
+
+	// Mouse: last click for double-click detection, preview wheel scroll
+	lastClickIndex int
+	lastClickAt    time.Time
+	previewSession string
+	previewOffset  int
+
 	// Captured pane content for preview
 	capturedContent map[string]string // paneID -> content
 }
 
-// AddPaneType returns the pane type to add after dashboard exits (empty if none)
-func (m Model) AddPaneType() domain.PaneType {
-	return m.addPaneType
-}
-
-// AttachSession returns the session name to attach to after dashboard exits (empty if none)
-func (m Model) AttachSession() string {
-	return m.attachSession
-}
-
-// OpenSessionPath returns the path to open a new session at after dashboard exits (empty if none)
-func (m Model) OpenSessionPath() string {
-	return m.openSessionPath
-}
-
-func NewModel(a *app.App) Model {
+func NewModel(a *app.App, km keys.Map) Model {
 	ti := textinput.New()
 	ti.Placeholder = "filter sessions..."
 	ti.CharLimit = 50
 	ti.Width = 20
 
+	si := textinput.New()
+	si.Placeholder = "message to send..."
+	si.CharLimit = 2000
+
 	return Model{
 		app:             a,
+		keys:            km,
 		tab:             TabSessions,
 		focus:           FocusLeft,
 		filterInput:     ti,
+		sendInput:       si,
+		markedPanes:     make(map[string]bool),
+		markedSessions:  make(map[string]bool),
 		capturedContent: make(map[string]string),
 	}
 }
 
+// SetPopup marks the dashboard as running inside a tmux popup.
+func (m *Model) SetPopup(popup bool) {
+	m.popup = popup
+}
+
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
-		m.refreshSnapshot(),
+		m.pollSnapshot(),
 		m.refreshTemplates(),
 		tea.EnterAltScreen,
 	)
 }
 
+// refreshSnapshot loads the snapshot once, e.g. after an action.
 func (m Model) refreshSnapshot() tea.Cmd {
+	return m.loadSnapshot(false)
+}
+
+// pollSnapshot loads the snapshot and schedules the next poll once it
+// arrives. Only Init and tickMsg start it, so there is a single poll loop.
+func (m Model) pollSnapshot() tea.Cmd {
+	return m.loadSnapshot(true)
+}
+
+func (m Model) loadSnapshot(poll bool) tea.Cmd {
 	return func() tea.Msg {
 		snapshot, err := m.app.Snapshot()
 		if err != nil {
-			return errMsg{err}
+			return errMsg{err: err, poll: poll}
 		}
-		return snapshotMsg{snapshot}
+		m.app.AnnotateGit(&snapshot)
+		return snapshotMsg{snapshot: snapshot, poll: poll}
 	}
 }
 
@@ -150,7 +206,7 @@ func (m Model) refreshTemplates() tea.Cmd {
 	return func() tea.Msg {
 		templates, err := m.app.ListTemplates()
 		if err != nil {
-			return errMsg{err}
+			return errMsg{err: err}
 		}
 		return templatesMsg{templates: templates}
 	}
@@ -158,14 +214,19 @@ func (m Model) refreshTemplates() tea.Cmd {
 
 type snapshotMsg struct {
 	snapshot domain.Snapshot
+	poll     bool
 }
 
 type errMsg struct {
-	err error
+	err  error
+	poll bool
 }
 
 type tickMsg struct{}
 
+// detachedMsg is sent when an attach run from the dashboard returns.
+type detachedMsg struct{}
+
 type templatesMsg struct {
 	templates []app.TemplateSummary
 }
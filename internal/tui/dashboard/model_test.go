@@ -0,0 +1,45 @@
+package dashboard
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/tui/dialogs"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+func TestRefreshSchedulesOnlyWhenPolling(t *testing.T) {
+	m := NewModel(nil, keys.Default())
+
+	cases := []struct {
+		name string
+		msg  tea.Msg
+		tick bool
+	}{
+		{"one-shot snapshot", snapshotMsg{}, false},
+		{"polled snapshot", snapshotMsg{poll: true}, true},
+		{"one-shot error", errMsg{err: errors.New("boom")}, false},
+		{"polled error", errMsg{err: errors.New("boom"), poll: true}, true},
+	}
+	for _, c := range cases {
+		_, cmd := m.Update(c.msg)
+		if got := cmd != nil; got != c.tick {
+			t.Errorf("%s: scheduled next poll = %v, want %v", c.name, got, c.tick)
+		}
+	}
+}
+
+func TestRefreshReachesDashboardUnderDialog(t *testing.T) {
+	m := NewModel(nil, keys.Default())
+	m.dialog = dialogs.NewRename("pane")
+
+	updated, cmd := m.Update(snapshotMsg{poll: true})
+	if cmd == nil {
+		t.Fatal("poll loop stopped while a dialog was open")
+	}
+	if updated.(Model).dialog == nil {
+		t.Fatal("refresh closed the dialog")
+	}
+}
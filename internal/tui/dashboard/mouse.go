@@ -0,0 +1,196 @@
+package dashboard
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const doubleClickInterval = 400 * time.Millisecond
+
+// Screen rows and insets of the dashboard layout built by renderDashboard.
+const (
+	sessionTabsRow = 0
+	modeTabsRow    = 1
+	panelTopRow    = 2
+	panelInset     = 2 // panel border plus padding
+	treeHeaderRows = 2 // "Sessions" title and the blank line below it
+
+	previewHeaderRows = 2 // preview title and the blank line below it
+	previewScroll     = 3 // lines per wheel step in the preview
+)
+
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	overLeft := msg.X < m.leftPanelWidth()
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		delta := 1
+		if msg.Button == tea.MouseButtonWheelUp {
+			delta = -1
+		}
+		if m.tab == TabTemplates {
+			m.templateIndex = clamp(m.templateIndex+delta, 0, len(m.templates)-1)
+			return m, nil
+		}
+		if overLeft {
+			m.treeIndex = clamp(m.treeIndex+delta, 0, len(m.buildTree())-1)
+			return m, nil
+		}
+		m = m.scrollPreviewBy(delta * previewScroll)
+		return m, nil
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch {
+		case msg.Y == sessionTabsRow:
+			if name := m.sessionTabAt(msg.X); name != "" {
+				m.selectSession(name)
+			}
+			return m, nil
+		case msg.Y == modeTabsRow:
+			if tab, ok := m.modeTabAt(msg.X); ok {
+				m.tab = tab
+				m.focus = FocusLeft
+			}
+			return m, nil
+		case m.tab == TabSessions && overLeft:
+			return m.clickTree(msg.Y)
+		}
+	}
+	return m, nil
+}
+
+// clickTree selects the tree row at screen row y. A second click on the same
+// row attaches to its session, selecting the pane when it is a pane row.
+func (m Model) clickTree(y int) (tea.Model, tea.Cmd) {
+	tree := m.buildTree()
+	index := y - m.treeTopRow()
+	if index < 0 || index >= len(tree) {
+		return m, nil
+	}
+
+	now := time.Now()
+	double := index == m.lastClickIndex && now.Sub(m.lastClickAt) < doubleClickInterval
+	m.treeIndex = index
+	m.lastClickIndex = index
+	m.lastClickAt = now
+	if !double {
+		return m, nil
+	}
+
+	m.lastClickAt = time.Time{}
+	item := tree[index]
+	switch item.Type {
+	case ItemGroup:
+		return m, nil
+	case ItemPane:
+		return m.attach(item.Session, item.Pane.ID, false)
+	}
+	return m.attach(item.Session, "", false)
+}
+
+// treeTopRow is the screen row of the first tree item.
+func (m Model) treeTopRow() int {
+	row := panelTopRow + panelInset + treeHeaderRows
+	if m.filterActive || strings.TrimSpace(m.filterInput.Value()) != "" {
+		row += 2
+	}
+	return row
+}
+
+// leftPanelWidth is the outer width of the left panel, borders included.
+func (m Model) leftPanelWidth() int {
+	return m.width/3 + 2
+}
+
+// sessionTabAt returns the session whose tab covers column x.
+func (m Model) sessionTabAt(x int) string {
+	names, tabs := m.sessionTabParts()
+	if len(tabs) == 0 {
+		return ""
+	}
+	col := lipgloss.Width(sessionTabsPrefix) + 1
+	for i, tab := range tabs {
+		w := lipgloss.Width(tab)
+		if x >= col && x < col+w {
+			return names[i]
+		}
+		col += w + 1
+	}
+	return ""
+}
+
+func (m Model) modeTabAt(x int) (Tab, bool) {
+	col := 0
+	for i, tab := range m.modeTabParts() {
+		w := lipgloss.Width(tab)
+		if x >= col && x < col+w {
+			return Tab(i), true
+		}
+		col += w
+	}
+	return TabSessions, false
+}
+
+// selectSession moves the tree cursor to a session's row.
+func (m *Model) selectSession(name string) {
+	for i, item := range m.buildTree() {
+		if item.Type == ItemSession && item.Session == name {
+			m.treeIndex = i
+			m.tab = TabSessions
+			return
+		}
+	}
+}
+
+func (m Model) scrollPreviewBy(delta int) Model {
+	session := m.selectedSession()
+	if session == nil {
+		return m
+	}
+	if m.previewSession != session.Name {
+		m.previewSession = session.Name
+		m.previewOffset = 0
+	}
+	m.previewOffset += delta
+	if m.previewOffset < 0 {
+		m.previewOffset = 0
+	}
+	return m
+}
+
+// scrollPreview drops the lines scrolled past with the mouse wheel, keeping
+// the preview title in place.
+func (m Model) scrollPreview(sessionName, preview string) string {
+	if m.previewOffset == 0 || m.previewSession != sessionName {
+		return preview
+	}
+	lines := strings.Split(preview, "\n")
+	if len(lines) <= previewHeaderRows {
+		return preview
+	}
+	body := lines[previewHeaderRows:]
+	offset := m.previewOffset
+	if offset > len(body)-1 {
+		offset = len(body) - 1
+	}
+	return strings.Join(append(lines[:previewHeaderRows:previewHeaderRows], body[offset:]...), "\n")
+}
+
+func clamp(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
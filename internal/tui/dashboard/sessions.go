@@ -0,0 +1,253 @@
+package dashboard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+)
+
+type sortMode int
+
+const (
+	sortName sortMode = iota
+	sortActivity
+	sortAttached
+	sortAttention
+)
+
+var sortModeNames = []string{"name", "activity", "attached", "attention"}
+
+type groupMode int
+
+const (
+	groupNone groupMode = iota
+	groupDirectory
+	groupRemote
+)
+
+var groupModeNames = []string{"none", "directory", "remote"}
+
+// filteredSessions returns the sessions matching the filter, pinned sessions
+// first and the rest in the current sort order.
+func (m Model) filteredSessions() []domain.Session {
+	terms := strings.Fields(strings.ToLower(m.filterInput.Value()))
+
+	var sessions []domain.Session
+	for _, s := range m.snapshot.Sessions {
+		if matchesFilter(s, terms) {
+			sessions = append(sessions, s)
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		switch m.sortMode {
+		case sortActivity:
+			if !a.LastActivity.Equal(b.LastActivity) {
+				return a.LastActivity.After(b.LastActivity)
+			}
+		case sortAttached:
+			if a.Attached != b.Attached {
+				return a.Attached
+			}
+		case sortAttention:
+			if na, nb := a.NeedsAttention(), b.NeedsAttention(); na != nb {
+				return na
+			}
+		}
+		return a.Name < b.Name
+	})
+	return sessions
+}
+
+// buildTree creates a flattened tree of sessions and their panes, under a
+// header per group when sessions are grouped
+func (m Model) buildTree() []TreeItem {
+	var items []TreeItem
+	addSession := func(session *domain.Session) {
+		items = append(items, TreeItem{
+			Type:    ItemSession,
+			Session: session.Name,
+		})
+		for i := range session.Panes {
+			items = append(items, TreeItem{
+				Type:    ItemPane,
+				Session: session.Name,
+				Pane:    &session.Panes[i],
+			})
+		}
+	}
+
+	sessions := m.filteredSessions()
+	if m.groupMode == groupNone {
+		for i := range sessions {
+			addSession(&sessions[i])
+		}
+		return items
+	}
+
+	// Groups appear in the order of their first session
+	var groups []string
+	members := map[string][]int{}
+	for i, s := range sessions {
+		g := m.groupLabel(s)
+		if _, ok := members[g]; !ok {
+			groups = append(groups, g)
+		}
+		members[g] = append(members[g], i)
+	}
+	for _, g := range groups {
+		items = append(items, TreeItem{Type: ItemGroup, Group: g})
+		for _, i := range members[g] {
+			addSession(&sessions[i])
+		}
+	}
+	return items
+}
+
+func (m Model) groupLabel(s domain.Session) string {
+	switch m.groupMode {
+	case groupDirectory:
+		if s.Path == "" {
+			return "(no path)"
+		}
+		return shortenHome(filepath.Dir(s.Path))
+	case groupRemote:
+		if s.Remote == "" {
+			return "(no remote)"
+		}
+		return strings.TrimSuffix(s.Remote, ".git")
+	}
+	return ""
+}
+
+func (m Model) cycleSort() Model {
+	name := m.cursorSession()
+	m.sortMode = (m.sortMode + 1) % sortMode(len(sortModeNames))
+	if m.tab == TabSessions {
+		m.selectSession(name)
+	}
+	m.statusMsg = fmt.Sprintf("sorted by %s", sortModeNames[m.sortMode])
+	return m
+}
+
+func (m Model) cycleGroup() Model {
+	name := m.cursorSession()
+	m.groupMode = (m.groupMode + 1) % groupMode(len(groupModeNames))
+	if m.tab == TabSessions {
+		m.selectSession(name)
+	}
+	m.statusMsg = fmt.Sprintf("grouped by %s", groupModeNames[m.groupMode])
+	return m
+}
+
+func (m Model) togglePin() (tea.Model, tea.Cmd) {
+	name := m.cursorSession()
+	if name == "" {
+		return m, nil
+	}
+	pinned, err := m.app.TogglePin(name)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	if pinned {
+		m.statusMsg = fmt.Sprintf("pinned %s", name)
+	} else {
+		m.statusMsg = fmt.Sprintf("unpinned %s", name)
+	}
+	m.followSession = name
+	return m, m.refreshSnapshot()
+}
+
+// cursorSession is the session of the tree item under the cursor.
+func (m Model) cursorSession() string {
+	if item := m.selectedTreeItem(); item != nil {
+		return item.Session
+	}
+	return ""
+}
+
+// matchesFilter reports whether every term matches the session. Session
+// names and pane titles match fuzzily; paths, the remote, pane types, tags
+// and notes only by substring, since long text would match almost any
+// subsequence. Tags match with or without their "#".
+func matchesFilter(s domain.Session, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	names := []string{s.Name}
+	fields := []string{s.Path, s.Remote, s.Note}
+	for _, tag := range s.Tags {
+		fields = append(fields, "#"+tag)
+	}
+	for _, p := range s.Panes {
+		names = append(names, p.Title)
+		fields = append(fields, string(p.Type), p.Path, p.CurrentPath, p.Note)
+		for _, tag := range p.Tags {
+			fields = append(fields, "#"+tag)
+		}
+	}
+	for _, term := range terms {
+		if !matchesTerm(term, names, fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTerm(term string, names, fields []string) bool {
+	for _, n := range names {
+		if fuzzyMatch(term, n) {
+			return true
+		}
+	}
+	for _, f := range fields {
+		if f != "" && strings.Contains(strings.ToLower(f), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyMatch reports whether the runes of term appear in s in order,
+// ignoring case. term must already be lowercase.
+func fuzzyMatch(term, s string) bool {
+	if term == "" {
+		return true
+	}
+	want := []rune(term)
+	i := 0
+	for _, r := range strings.ToLower(s) {
+		if r == want[i] {
+			i++
+			if i == len(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func shortenHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "~" + path[len(home):]
+	}
+	return path
+}
@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minghinmatthewlam/agentpane/internal/domain"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		term, s string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"api", "api", true},
+		{"aps", "agentpane-api-server", true},
+		{"clde", "Claude-1", true},
+		{"edualc", "claude", false},
+		{"apix", "api", false},
+		{"é", "CAFÉ", true},
+	}
+	for _, c := range cases {
+		if got := fuzzyMatch(c.term, c.s); got != c.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", c.term, c.s, got, c.want)
+		}
+	}
+}
+
+func TestMatchesFilter(t *testing.T) {
+	s := domain.Session{
+		Name:   "billing",
+		Path:   "/home/dev/src/billing-service",
+		Remote: "git@github.com:acme/billing.git",
+		Tags:   []string{"urgent"},
+		Note:   "waiting on stripe webhook fix",
+		Panes: []domain.Pane{
+			{Title: "claude-review", Type: domain.PaneClaude, CurrentPath: "/home/dev/src/billing-service/api"},
+		},
+	}
+
+	cases := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"blng", true},          // fuzzy on the session name
+		{"clrev", true},         // fuzzy on a pane title
+		{"service/api", true},   // substring of a path
+		{"acme", true},          // substring of the remote
+		{"stripe", true},        // substring of the note
+		{"#urgent", true},       // tag with its "#"
+		{"urg", true},           // tag without it
+		{"claude", true},        // pane type
+		{"blng stripe", true},   // every term must match
+		{"blng nothing", false}, // one term misses
+		{"hdsv", false},         // would match the path only as a subsequence
+		{"wtng", false},         // would match the note only as a subsequence
+	}
+	for _, c := range cases {
+		if got := matchesFilter(s, strings.Fields(c.filter)); got != c.want {
+			t.Errorf("matchesFilter(%q) = %v, want %v", c.filter, got, c.want)
+		}
+	}
+}
+
+func TestFilteredSessionsSort(t *testing.T) {
+	now := time.Now()
+	m := NewModel(nil, keys.Default())
+	m.snapshot = domain.Snapshot{Sessions: []domain.Session{
+		{Name: "c", LastActivity: now.Add(-time.Minute), Panes: []domain.Pane{
+			{Type: domain.PaneClaude, Status: domain.StatusExited},
+		}},
+		{Name: "b", Attached: true, LastActivity: now.Add(-time.Hour)},
+		{Name: "a", LastActivity: now.Add(-2 * time.Hour), Panes: []domain.Pane{
+			{Type: domain.PaneShell, Status: domain.StatusExited},
+		}},
+		{Name: "d", Pinned: true, LastActivity: now.Add(-3 * time.Hour)},
+	}}
+
+	cases := []struct {
+		mode sortMode
+		want []string
+	}{
+		{sortName, []string{"d", "a", "b", "c"}},
+		{sortActivity, []string{"d", "c", "b", "a"}},
+		{sortAttached, []string{"d", "b", "a", "c"}},
+		{sortAttention, []string{"d", "c", "a", "b"}},
+	}
+	for _, c := range cases {
+		m.sortMode = c.mode
+		var got []string
+		for _, s := range m.filteredSessions() {
+			got = append(got, s.Name)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("sort by %s = %v, want %v", sortModeNames[c.mode], got, c.want)
+		}
+	}
+}
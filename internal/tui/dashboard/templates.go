@@ -0,0 +1,127 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/dialogs"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+// handleTemplateKey handles the template editing keys on the Templates tab.
+// ok is false for keys it does not handle.
+func (m Model) handleTemplateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	key, km := msg.String(), m.keys
+	switch {
+	case km.Matches(key, keys.NewTemplate):
+		tmpl := config.Template{Panes: []config.PaneSpec{{Type: "claude"}}}
+		m.dialog = dialogs.NewTemplateEditor("New template", "", "", tmpl)
+		return m, m.dialog.Init(), true
+	case km.Matches(key, keys.EditTemplate), km.Matches(key, keys.CopyTemplate):
+		selected := m.selectedTemplate()
+		if selected == nil {
+			return m, nil, true
+		}
+		tmpl, err := m.app.RawTemplate(selected.Name)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil, true
+		}
+		if km.Matches(key, keys.CopyTemplate) {
+			m.dialog = dialogs.NewTemplateEditor("Duplicate template", m.copyName(selected.Name), "", tmpl)
+		} else {
+			m.dialog = dialogs.NewTemplateEditor("Edit template", selected.Name, selected.Name, tmpl)
+		}
+		return m, m.dialog.Init(), true
+	case km.Matches(key, keys.DeleteTemplate):
+		selected := m.selectedTemplate()
+		if selected == nil {
+			return m, nil, true
+		}
+		if selected.Source == app.TemplateBuiltin {
+			m.errorMsg = fmt.Sprintf("template %q is built in and cannot be deleted", selected.Name)
+			return m, nil, true
+		}
+		m.confirmAction = confirmDeleteTemplate
+		m.confirmTemplate = selected.Name
+		m.dialog = dialogs.NewConfirm(
+			"Delete template?",
+			fmt.Sprintf("Remove template %s from the %s config?", selected.Name, selected.Source),
+		)
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// copyName picks an unused name for a duplicate of the named template.
+func (m Model) copyName(name string) string {
+	taken := make(map[string]bool, len(m.templates))
+	for _, t := range m.templates {
+		taken[t.Name] = true
+	}
+	candidate := name + "-copy"
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-copy%d", name, i)
+	}
+	return candidate
+}
+
+func (m Model) saveTemplate(result dialogs.TemplateEditorResult) (tea.Model, tea.Cmd) {
+	if result.Name != result.Previous {
+		for _, t := range m.templates {
+			if t.Name == result.Name && t.Source != app.TemplateBuiltin {
+				m.errorMsg = fmt.Sprintf("template %q already exists", result.Name)
+				return m, nil
+			}
+		}
+	}
+	if err := m.app.SaveTemplate(result.Name, result.Previous, result.Template); err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("template '%s' saved", result.Name)
+	m.followTemplate = result.Name
+	return m, m.refreshTemplates()
+}
+
+func (m Model) deleteTemplate(name string) (tea.Model, tea.Cmd) {
+	if err := m.app.DeleteTemplate(name); err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("template '%s' deleted", name)
+	return m, m.refreshTemplates()
+}
+
+// planSummary describes a reconcile plan for the apply confirmation.
+func planSummary(plan []app.PlanStep) string {
+	var keep, add, extra []string
+	for _, step := range plan {
+		switch step.Action {
+		case app.PlanKeep:
+			keep = append(keep, step.Title)
+		case app.PlanAdd:
+			add = append(add, step.Title)
+		case app.PlanExtra:
+			extra = append(extra, step.Title)
+		}
+	}
+
+	var lines []string
+	if len(keep) > 0 {
+		lines = append(lines, "Keep running: "+strings.Join(keep, ", "))
+	}
+	if len(add) > 0 {
+		lines = append(lines, "Add: "+strings.Join(add, ", "))
+	} else {
+		lines = append(lines, "Nothing to add.")
+	}
+	if len(extra) > 0 {
+		lines = append(lines, "Not in template (left as is): "+strings.Join(extra, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
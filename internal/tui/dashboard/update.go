@@ -5,14 +5,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/minghinmatthewlam/agentpane/internal/app"
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
 	"github.com/minghinmatthewlam/agentpane/internal/tui/dialogs"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/diffview"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/viewer"
 )
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Refreshes are handled whatever is open, so the poll loop never stalls
+	if updated, cmd, ok := m.handleRefresh(msg); ok {
+		return updated, cmd
+	}
+
+	if m.viewer != nil {
+		return m.updateViewer(msg)
+	}
+
 	if m.dialog != nil {
 		return m.updateDialog(msg)
 	}
@@ -22,37 +35,76 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleFilterInput(msg)
 	}
 
+	// Keys go to the send box while it is open; refreshes keep running
+	if key, ok := msg.(tea.KeyMsg); ok && m.sendActive {
+		return m.handleSendInput(key)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.tooNarrow = msg.Width < minWidth || msg.Height < minHeight
 		return m, nil
+	}
+
+	return m, nil
+}
+
+// handleRefresh applies snapshot, template and capture results.
+func (m Model) handleRefresh(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
 	case snapshotMsg:
 		m.snapshot = msg.snapshot
+		m = m.pruneMarks()
 		m.errorMsg = ""
+		if m.followSession != "" {
+			m.selectSession(m.followSession)
+			m.followSession = ""
+		}
 		// Capture pane content for preview
-		cmds := []tea.Cmd{m.scheduleRefresh()}
+		var cmds []tea.Cmd
+		if msg.poll {
+			cmds = append(cmds, m.scheduleRefresh())
+		}
 		if capCmd := m.capturePaneContent(); capCmd != nil {
 			cmds = append(cmds, capCmd)
 		}
-		return m, tea.Batch(cmds...)
+		return m, tea.Batch(cmds...), true
 	case capturedContentMsg:
 		m.capturedContent = msg.content
-		return m, nil
+		return m, nil, true
 	case errMsg:
 		m.errorMsg = msg.err.Error()
-		return m, m.scheduleRefresh()
+		if msg.poll {
+			return m, m.scheduleRefresh(), true
+		}
+		return m, nil, true
 	case templatesMsg:
 		m.templates = msg.templates
-		return m, nil
+		for i, t := range m.templates {
+			if t.Name == m.followTemplate {
+				m.templateIndex = i
+			}
+		}
+		m.followTemplate = ""
+		if m.templateIndex >= len(m.templates) {
+			m.templateIndex = len(m.templates) - 1
+		}
+		if m.templateIndex < 0 {
+			m.templateIndex = 0
+		}
+		return m, nil, true
 	case tickMsg:
-		return m, m.refreshSnapshot()
+		return m, m.pollSnapshot(), true
+	case detachedMsg:
+		return m, m.refreshSnapshot(), true
 	}
-
-	return m, nil
+	return m, nil, false
 }
 
 type capturedContentMsg struct {
@@ -60,10 +112,29 @@ type capturedContentMsg struct {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	// With items marked, action keys apply to all of them
+	if m.tab == TabSessions && m.markCount() > 0 {
+		if updated, cmd, ok := m.handleBulkKey(msg); ok {
+			return updated, cmd
+		}
+	}
+
+	if m.tab == TabTemplates {
+		if updated, cmd, ok := m.handleTemplateKey(msg); ok {
+			return updated, cmd
+		}
+	}
+
+	key, km := msg.String(), m.keys
+	// Sessions tab actions share keys with template actions, so they only
+	// apply on their own tab
+	match := func(a keys.Action) bool {
+		return km.Matches(key, a) && (m.tab == TabSessions || keys.ScopeOf(a) != keys.ScopeSessions)
+	}
+	switch {
+	case match(keys.Quit):
 		return m, tea.Quit
-	case "tab":
+	case match(keys.SwitchTab):
 		// Tab switches between Sessions and Templates tabs
 		if m.tab == TabSessions {
 			m.tab = TabTemplates
@@ -72,7 +143,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.tab = TabSessions
 		}
 		return m, nil
-	case "left", "h":
+	case match(keys.PrevSession):
 		// Jump to previous session in tree
 		if m.tab == TabSessions {
 			tree := m.buildTree()
@@ -85,7 +156,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "right", "l":
+	case match(keys.NextSession):
 		// Jump to next session in tree
 		if m.tab == TabSessions {
 			tree := m.buildTree()
@@ -98,7 +169,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "up":
+	case match(keys.Up):
 		if m.tab == TabTemplates {
 			if m.focus == FocusLeft {
 				if m.templateIndex > 0 {
@@ -112,7 +183,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "down":
+	case match(keys.Down):
 		if m.tab == TabTemplates {
 			if m.focus == FocusLeft {
 				if m.templateIndex < len(m.templates)-1 {
@@ -127,7 +198,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "k":
+	case match(keys.KillSession):
 		// Kill session (when cursor is on a session)
 		if m.tab == TabSessions {
 			item := m.selectedTreeItem()
@@ -141,11 +212,45 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "enter":
+	case match(keys.Send):
+		// Send input to the selected pane
+		if pane := m.selectedPane(); pane != nil {
+			item := m.selectedTreeItem()
+			m.sendActive = true
+			m.sendSession = item.Session
+			m.sendPaneID = pane.ID
+			m.sendTitle = pane.Title
+			m.sendHistory = m.app.InputHistory(item.Session, pane.ID)
+			m.sendHistoryIndex = len(m.sendHistory)
+			m.sendInput.SetValue("")
+			m.sendInput.Focus()
+			return m, textinput.Blink
+		}
+		return m, nil
+	case match(keys.Jump) || match(keys.JumpZoom):
+		// Jump to the selected pane, zooming it for jump_zoom
+		if pane := m.selectedPane(); pane != nil {
+			return m.attach(m.selectedTreeItem().Session, pane.ID, km.Matches(key, keys.JumpZoom))
+		}
+		return m, nil
+	case match(keys.View):
+		if pane := m.selectedPane(); pane != nil {
+			return m.openViewer(pane)
+		}
+		return m, nil
+	case match(keys.Diff):
+		if pane := m.selectedPane(); pane != nil {
+			return m.openDiff(m.selectedTreeItem().Session, pane)
+		}
+		return m, nil
+	case match(keys.Select):
 		if m.tab == TabSessions {
+			// Enter on a pane opens the full-screen viewer
+			if pane := m.selectedPane(); pane != nil {
+				return m.openViewer(pane)
+			}
 			if session := m.selectedSession(); session != nil {
-				m.attachSession = session.Name
-				return m, tea.Quit
+				return m.attach(session.Name, "", false)
 			}
 		} else if m.tab == TabTemplates {
 			if tmpl := m.selectedTemplate(); tmpl != nil {
@@ -154,21 +259,34 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.errorMsg = "no session selected"
 					return m, nil
 				}
+				// Show what reconciling would change before doing it
+				plan, err := m.app.ApplyTemplate(app.ApplyTemplateOptions{
+					Session:   sessionName,
+					Template:  tmpl.Name,
+					Reconcile: true,
+					DryRun:    true,
+				})
+				if err != nil {
+					m.errorMsg = err.Error()
+					return m, nil
+				}
 				m.confirmAction = confirmApplyTemplate
 				m.confirmSession = sessionName
 				m.confirmTemplate = tmpl.Name
 				m.dialog = dialogs.NewConfirm(
 					"Apply template?",
-					fmt.Sprintf("Replace panes in session %s with template %s?", sessionName, tmpl.Name),
+					fmt.Sprintf("Apply template %s to session %s?\n\n%s", tmpl.Name, sessionName, planSummary(plan.Plan)),
 				)
 				return m, nil
 			}
 		}
 		return m, nil
-	case "a":
+	case match(keys.Mark):
+		return m.toggleMark()
+	case match(keys.AddPane):
 		m.dialog = dialogs.NewAddPane()
 		return m, nil
-	case "r":
+	case match(keys.Rename):
 		// Rename only works when cursor is on a pane
 		if pane := m.selectedPane(); pane != nil {
 			session := m.selectedSession()
@@ -179,19 +297,42 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "c":
+	case match(keys.Tags):
+		if m.tab == TabSessions {
+			return m.editMetadata(dialogs.FieldTags)
+		}
+		return m, nil
+	case match(keys.Note):
+		if m.tab == TabSessions {
+			return m.editMetadata(dialogs.FieldNote)
+		}
+		return m, nil
+	case match(keys.AddClaude):
 		// Quick-add Claude pane
-		m.addPaneType = domain.PaneClaude
-		return m, tea.Quit
-	case "x":
+		return m.addPane(domain.PaneClaude)
+	case match(keys.AddCodex):
 		// Quick-add Codex pane
-		m.addPaneType = domain.PaneCodex
-		return m, tea.Quit
-	case "s":
+		return m.addPane(domain.PaneCodex)
+	case match(keys.AddShell):
 		// Quick-add Shell pane
-		m.addPaneType = domain.PaneShell
-		return m, tea.Quit
-	case "d":
+		return m.addPane(domain.PaneShell)
+	case match(keys.Restart):
+		// Restart pane in place; running agents are confirmed first
+		if pane := m.selectedPane(); pane != nil {
+			item := m.selectedTreeItem()
+			if pane.Status == domain.StatusExited {
+				return m.restartPane(item.Session, pane.ID)
+			}
+			m.confirmAction = confirmRestartPane
+			m.confirmSession = item.Session
+			m.confirmPaneID = pane.ID
+			m.dialog = dialogs.NewConfirm(
+				"Restart pane?",
+				fmt.Sprintf("This will kill the running process in '%s' and relaunch it.", pane.Title),
+			)
+		}
+		return m, nil
+	case match(keys.ClosePane):
 		// Delete/close pane - only works when cursor is on a pane
 		if pane := m.selectedPane(); pane != nil {
 			m.confirmAction = confirmClosePane
@@ -202,14 +343,20 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			)
 		}
 		return m, nil
-	case "o":
+	case match(keys.OpenSession):
 		// Open new session
 		m.dialog = dialogs.NewOpenSession()
 		return m, nil
-	case "?":
-		m.dialog = dialogs.NewHelp()
+	case match(keys.Help):
+		m.dialog = dialogs.NewHelp(m.keys.HelpText())
 		return m, nil
-	case "/":
+	case match(keys.Sort):
+		return m.cycleSort(), nil
+	case match(keys.Group):
+		return m.cycleGroup(), nil
+	case match(keys.Pin):
+		return m.togglePin()
+	case match(keys.Filter):
 		// Activate session filter
 		m.filterActive = true
 		m.filterInput.Focus()
@@ -219,6 +366,86 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleSendInput handles keys while the inline send box is open. Enter sends
+// the text, up/down recall history and ctrl keys send common control input.
+func (m Model) handleSendInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.sendActive = false
+		m.sendBroadcast = false
+		m.sendInput.Blur()
+		return m, nil
+	case "enter":
+		text := m.sendInput.Value()
+		if m.sendBroadcast {
+			m.sendActive = false
+			m.sendBroadcast = false
+			m.sendInput.Blur()
+			return m.confirmBroadcastDialog(text)
+		}
+		if err := m.app.SendInput(m.sendSession, m.sendPaneID, text); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		if strings.TrimSpace(text) != "" {
+			if n := len(m.sendHistory); n == 0 || m.sendHistory[n-1] != text {
+				m.sendHistory = append(m.sendHistory, text)
+			}
+		}
+		m.sendHistoryIndex = len(m.sendHistory)
+		m.sendInput.SetValue("")
+		m.errorMsg = ""
+		m.statusMsg = fmt.Sprintf("sent to %s", m.sendTitle)
+		return m, m.refreshSnapshot()
+	case "up":
+		if m.sendHistoryIndex > 0 {
+			m.sendHistoryIndex--
+			m.sendInput.SetValue(m.sendHistory[m.sendHistoryIndex])
+			m.sendInput.CursorEnd()
+		}
+		return m, nil
+	case "down":
+		if m.sendHistoryIndex < len(m.sendHistory)-1 {
+			m.sendHistoryIndex++
+			m.sendInput.SetValue(m.sendHistory[m.sendHistoryIndex])
+		} else {
+			m.sendHistoryIndex = len(m.sendHistory)
+			m.sendInput.SetValue("")
+		}
+		m.sendInput.CursorEnd()
+		return m, nil
+	}
+
+	key, km := msg.String(), m.keys
+	switch {
+	case km.Matches(key, keys.SendCtrlC):
+		return m.sendQuickKeys("Ctrl-C", "C-c")
+	case km.Matches(key, keys.SendEsc):
+		return m.sendQuickKeys("Esc", "Escape")
+	case km.Matches(key, keys.SendYes):
+		return m.sendQuickKeys("y", "y", "Enter")
+	case km.Matches(key, keys.SendNo):
+		return m.sendQuickKeys("n", "n", "Enter")
+	}
+
+	var cmd tea.Cmd
+	m.sendInput, cmd = m.sendInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) sendQuickKeys(label string, keys ...string) (tea.Model, tea.Cmd) {
+	if m.sendBroadcast {
+		return m.broadcastKeys(label, keys...)
+	}
+	if err := m.app.SendKeys(m.sendPaneID, keys...); err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	m.errorMsg = ""
+	m.statusMsg = fmt.Sprintf("sent %s to %s", label, m.sendTitle)
+	return m, m.refreshSnapshot()
+}
+
 func (m Model) handleFilterInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -250,42 +477,6 @@ func (m Model) handleFilterInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// filteredSessions returns sessions matching the current filter
-func (m Model) filteredSessions() []domain.Session {
-	filter := strings.ToLower(strings.TrimSpace(m.filterInput.Value()))
-	if filter == "" {
-		return m.snapshot.Sessions
-	}
-
-	var filtered []domain.Session
-	for _, s := range m.snapshot.Sessions {
-		if strings.Contains(strings.ToLower(s.Name), filter) {
-			filtered = append(filtered, s)
-		}
-	}
-	return filtered
-}
-
-// buildTree creates a flattened tree of sessions and their panes
-func (m Model) buildTree() []TreeItem {
-	filtered := m.filteredSessions()
-	var items []TreeItem
-	for _, session := range filtered {
-		items = append(items, TreeItem{
-			Type:    ItemSession,
-			Session: session.Name,
-		})
-		for i := range session.Panes {
-			items = append(items, TreeItem{
-				Type:    ItemPane,
-				Session: session.Name,
-				Pane:    &session.Panes[i],
-			})
-		}
-	}
-	return items
-}
-
 // selectedTreeItem returns the currently selected tree item
 func (m Model) selectedTreeItem() *TreeItem {
 	tree := m.buildTree()
@@ -359,19 +550,16 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Cancelled {
 			return m, nil
 		}
-		result, err := m.app.Add(app.AddOptions{Type: domain.PaneType(msg.Type)})
-		if err != nil {
-			m.errorMsg = err.Error()
-			return m, nil
-		}
-		if result.FellBackToShell {
-			m.statusMsg = "provider unavailable; created shell pane"
-		} else {
-			m.statusMsg = fmt.Sprintf("created pane %s", result.Title)
-		}
-		return m, m.refreshSnapshot()
+		return m.addPane(domain.PaneType(msg.Type))
 	case dialogs.RenameResult:
 		m.dialog = nil
+		if m.bulkRename {
+			m.bulkRename = false
+			if msg.Cancelled {
+				return m, nil
+			}
+			return m.confirmBulkRenameDialog(msg.Title)
+		}
 		if msg.Cancelled {
 			m.renameSession = ""
 			m.renamePaneID = ""
@@ -393,6 +581,9 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.renamePaneID = ""
 		m.statusMsg = "pane renamed"
 		return m, m.refreshSnapshot()
+	case dialogs.MetadataResult:
+		m.dialog = nil
+		return m.saveMetadata(msg)
 	case dialogs.OpenSessionResult:
 		m.dialog = nil
 		if msg.Cancelled {
@@ -402,16 +593,23 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorMsg = "path cannot be empty"
 			return m, nil
 		}
-		// Store the path and quit - session will be created after dashboard exits
-		m.openSessionPath = msg.Path
-		return m, tea.Quit
+		result, err := m.app.Up(app.UpOptions{Cwd: msg.Path, Detach: true, NoPrompt: true})
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("failed to open session: %v", err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("session '%s' %s", result.SessionName, result.Action)
+		return m, m.refreshSnapshot()
 	case dialogs.ConfirmResult:
 		m.dialog = nil
 		if !msg.Accepted {
 			m.confirmAction = confirmNone
+			m.bulkText = ""
 			return m, nil
 		}
 		switch m.confirmAction {
+		case confirmBulkClose, confirmBulkRestart, confirmBulkRename, confirmBulkBroadcast, confirmBulkKill:
+			return m.runBulk(m.confirmAction)
 		case confirmClosePane:
 			if err := m.app.ClosePane(m.confirmPaneID); err != nil {
 				m.errorMsg = err.Error()
@@ -422,9 +620,9 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.refreshSnapshot()
 		case confirmApplyTemplate:
 			_, err := m.app.ApplyTemplate(app.ApplyTemplateOptions{
-				Session:  m.confirmSession,
-				Template: m.confirmTemplate,
-				Force:    true,
+				Session:   m.confirmSession,
+				Template:  m.confirmTemplate,
+				Reconcile: true,
 			})
 			if err != nil {
 				m.errorMsg = err.Error()
@@ -433,8 +631,13 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// Auto-attach to the session after applying template
 			m.confirmAction = confirmNone
-			m.attachSession = m.confirmSession
-			return m, tea.Quit
+			return m.attach(m.confirmSession, "", false)
+		case confirmRestartPane:
+			m.confirmAction = confirmNone
+			return m.restartPane(m.confirmSession, m.confirmPaneID)
+		case confirmDeleteTemplate:
+			m.confirmAction = confirmNone
+			return m.deleteTemplate(m.confirmTemplate)
 		case confirmKillSession:
 			if err := m.app.KillSession(m.confirmSession); err != nil {
 				m.errorMsg = err.Error()
@@ -448,6 +651,12 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 		default:
 			m.confirmAction = confirmNone
 		}
+	case dialogs.TemplateEditorResult:
+		m.dialog = nil
+		if msg.Cancelled {
+			return m, nil
+		}
+		return m.saveTemplate(msg)
 	case dialogs.HelpResult:
 		m.dialog = nil
 		return m, nil
@@ -456,6 +665,112 @@ func (m Model) updateDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) openViewer(pane *domain.Pane) (tea.Model, tea.Cmd) {
+	v := viewer.New(m.app, pane.ID, pane.Title, m.width, m.height)
+	m.viewer = v
+	return m, v.Init()
+}
+
+// openDiff opens the change review for the directory the pane works in.
+func (m Model) openDiff(session string, pane *domain.Pane) (tea.Model, tea.Cmd) {
+	dir, err := m.app.PaneDir(app.PaneRef{Session: session, PaneID: pane.ID, Title: pane.Title})
+	if err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	v := diffview.New(m.app, m.keys, dir, pane.Title, m.width, m.height)
+	m.viewer = v
+	return m, v.Init()
+}
+
+// updateViewer routes input to the open viewer. Other messages also reach the
+// dashboard so its snapshot keeps refreshing underneath.
+func (m Model) updateViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case viewer.CloseMsg, diffview.CloseMsg:
+		m.viewer = nil
+		return m, m.refreshSnapshot()
+	}
+
+	var cmd tea.Cmd
+	m.viewer, cmd = m.viewer.Update(msg)
+
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		return m, cmd
+	}
+
+	v := m.viewer
+	m.viewer = nil
+	updated, dashCmd := m.Update(msg)
+	dm := updated.(Model)
+	dm.viewer = v
+	return dm, tea.Batch(cmd, dashCmd)
+}
+
+// addPane adds a pane of type t to the session under the cursor.
+func (m Model) addPane(t domain.PaneType) (tea.Model, tea.Cmd) {
+	session := ""
+	if s := m.selectedSession(); s != nil {
+		session = s.Name
+	}
+	if session == "" {
+		m.errorMsg = "no session selected"
+		return m, nil
+	}
+	result, err := m.app.Add(app.AddOptions{Type: t, Session: session})
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("failed to add pane: %v", err)
+		return m, nil
+	}
+	if result.FellBackToShell {
+		m.statusMsg = fmt.Sprintf("%s not found in PATH; created shell pane %s", t, result.Title)
+	} else {
+		m.statusMsg = fmt.Sprintf("created pane %s in %s", result.Title, session)
+	}
+	return m, m.refreshSnapshot()
+}
+
+// attach switches to a session, selecting paneID first when set. Inside tmux
+// the client switches and the dashboard keeps running in its window, or
+// closes when it is a popup; outside tmux the dashboard is suspended while
+// attached and resumes on detach.
+func (m Model) attach(session, paneID string, zoom bool) (tea.Model, tea.Cmd) {
+	if paneID != "" {
+		if err := m.app.SelectPane(paneID, zoom); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+	}
+	if m.app.InTmux() {
+		if err := m.app.Attach(session); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		if m.popup {
+			return m, tea.Quit
+		}
+		m.statusMsg = fmt.Sprintf("switched to %s", session)
+		return m, m.refreshSnapshot()
+	}
+	return m, tea.ExecProcess(m.app.AttachCommand(session), func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return detachedMsg{}
+	})
+}
+
+func (m Model) restartPane(session, paneID string) (tea.Model, tea.Cmd) {
+	result, err := m.app.RestartPane(app.RestartOptions{Session: session, PaneID: paneID})
+	if err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("restarted pane %s", result.Title)
+	return m, m.refreshSnapshot()
+}
+
 // capturePaneContent captures content from panes in the selected session
 func (m Model) capturePaneContent() tea.Cmd {
 	session := m.selectedSession()
@@ -2,12 +2,16 @@ package dashboard
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/minghinmatthewlam/agentpane/internal/app"
 	"github.com/minghinmatthewlam/agentpane/internal/domain"
 	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
 )
 
 func (m Model) View() string {
@@ -15,6 +19,10 @@ func (m Model) View() string {
 		return m.renderTooNarrow()
 	}
 
+	if m.viewer != nil {
+		return m.viewer.View()
+	}
+
 	if m.dialog != nil {
 		return m.renderWithDialog()
 	}
@@ -52,6 +60,9 @@ func (m Model) renderDashboard() string {
 	content := lipgloss.JoinHorizontal(lipgloss.Top, leftStyled, rightStyled)
 
 	status := m.renderStatus()
+	if m.sendActive {
+		status = lipgloss.JoinVertical(lipgloss.Left, m.renderSendInput(), status)
+	}
 	footer := m.renderFooter()
 	if status != "" {
 		return lipgloss.JoinVertical(lipgloss.Left, header, content, status, footer)
@@ -62,6 +73,16 @@ func (m Model) renderDashboard() string {
 func (m Model) renderTree() string {
 	var b strings.Builder
 	b.WriteString(common.TitleStyle.Render("Sessions"))
+	var modes []string
+	if m.sortMode != sortName {
+		modes = append(modes, "sort: "+sortModeNames[m.sortMode])
+	}
+	if m.groupMode != groupNone {
+		modes = append(modes, "group: "+groupModeNames[m.groupMode])
+	}
+	if len(modes) > 0 {
+		b.WriteString(common.DimSelectedStyle.Render("  " + strings.Join(modes, ", ")))
+	}
 	b.WriteString("\n\n")
 
 	// Show filter input if active or has value
@@ -87,22 +108,40 @@ func (m Model) renderTree() string {
 	}
 
 	for i, item := range tree {
-		cursor := "  "
+		cursor := " "
 		style := common.NormalStyle
 		if i == m.treeIndex {
-			cursor = "→ "
+			cursor = "→"
 			style = common.SelectedStyle
 		}
+		if m.isMarked(item) {
+			cursor += "✓"
+		} else {
+			cursor += " "
+		}
 
-		if item.Type == ItemSession {
+		if item.Type == ItemGroup {
+			line := ansi.Truncate(fmt.Sprintf("%s▾ %s", cursor, item.Group), m.treeWidth(), "…")
+			if i == m.treeIndex {
+				b.WriteString(style.Render(line))
+			} else {
+				b.WriteString(common.DimSelectedStyle.Render(line))
+			}
+		} else if item.Type == ItemSession {
 			// Session row
 			indicator := "○"
+			pinned := false
+			var gitInfo *domain.GitInfo
+			var meta string
 			// Check if any pane in this session is active
 			for j := range m.snapshot.Sessions {
 				if m.snapshot.Sessions[j].Name == item.Session {
 					if sessionHasActive(m.snapshot.Sessions[j]) {
 						indicator = "●"
 					}
+					pinned = m.snapshot.Sessions[j].Pinned
+					gitInfo = m.snapshot.Sessions[j].Git
+					meta = metadataLabel(m.snapshot.Sessions[j].Tags, m.snapshot.Sessions[j].Note)
 					break
 				}
 			}
@@ -112,9 +151,21 @@ func (m Model) renderTree() string {
 			if item.Session == m.snapshot.CurrentSession {
 				name = "● " + name
 			}
+			if profile := m.sessionProfile(item.Session); profile != "" {
+				name += " (" + profile + ")"
+			}
+			if pinned {
+				name += " ★"
+			}
 
 			line := fmt.Sprintf("%s%s %s", cursor, indicator, name)
-			b.WriteString(style.Render(line))
+			if label := gitLabel(gitInfo); label != "" {
+				line += " " + label
+			}
+			if meta != "" {
+				line += " " + meta
+			}
+			b.WriteString(style.Render(ansi.Truncate(line, m.treeWidth(), "…")))
 		} else {
 			// Pane row (indented)
 			pane := item.Pane
@@ -127,7 +178,19 @@ func (m Model) renderTree() string {
 
 			typeBadge := fmt.Sprintf("[%s]", pane.Type)
 			line := fmt.Sprintf("%s    %s %s %s", cursor, indicator, pane.Title, typeBadge)
-			b.WriteString(style.Render(line))
+			if dir := m.paneDirLabel(item.Session, pane); dir != "" {
+				line += " " + dir
+			}
+			if pane.Restarts > 0 {
+				line += fmt.Sprintf(" ↻%d", pane.Restarts)
+			}
+			if label := m.paneGitLabel(item.Session, pane); label != "" {
+				line += " " + label
+			}
+			if meta := metadataLabel(pane.Tags, pane.Note); meta != "" {
+				line += " " + meta
+			}
+			b.WriteString(style.Render(ansi.Truncate(line, m.treeWidth(), "…")))
 		}
 		b.WriteString("\n")
 	}
@@ -149,6 +212,16 @@ func (m Model) renderPanePreview() string {
 
 	var b strings.Builder
 	b.WriteString(common.TitleStyle.Render(fmt.Sprintf("Preview: %s", session.Name)))
+	if session.Profile != "" {
+		b.WriteString(common.DimSelectedStyle.Render(fmt.Sprintf("  profile: %s", session.Profile)))
+	}
+	if g := session.Git; g != nil {
+		summary := "  " + gitLabel(g)
+		if g.Subject != "" {
+			summary += " · " + g.Subject
+		}
+		b.WriteString(common.DimSelectedStyle.Render(summary))
+	}
 	b.WriteString("\n\n")
 
 	if len(session.Panes) == 0 {
@@ -172,7 +245,7 @@ func (m Model) renderPanePreview() string {
 
 	paneStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(common.ColorBorder).
 		Padding(0, 1)
 
 	for _, pane := range session.Panes {
@@ -182,6 +255,9 @@ func (m Model) renderPanePreview() string {
 			indicator = "●"
 		}
 		header := fmt.Sprintf("%s %s [%s]", indicator, pane.Title, pane.Type)
+		if label := m.paneGitLabel(session.Name, &pane); label != "" {
+			header += " " + label
+		}
 		b.WriteString(common.DimSelectedStyle.Render(header))
 		b.WriteString("\n")
 
@@ -215,7 +291,7 @@ func (m Model) renderPanePreview() string {
 		b.WriteString("\n\n")
 	}
 
-	return b.String()
+	return m.scrollPreview(session.Name, b.String())
 }
 
 func (m Model) renderHeader() string {
@@ -223,25 +299,38 @@ func (m Model) renderHeader() string {
 	sessionBar := m.renderSessionTabs()
 
 	// Line 2: Mode tabs (Sessions/Templates)
+	modeTabs := lipgloss.JoinHorizontal(lipgloss.Top, m.modeTabParts()...)
+
+	return lipgloss.JoinVertical(lipgloss.Left, sessionBar, modeTabs)
+}
+
+// modeTabParts renders the Sessions/Templates tabs, in Tab order.
+func (m Model) modeTabParts() []string {
 	tabs := []string{"Sessions", "Templates"}
 	var tabViews []string
 	for i, tab := range tabs {
 		style := common.TabStyle
-		if (i == 0 && m.tab == TabSessions) || (i == 1 && m.tab == TabTemplates) {
+		if Tab(i) == m.tab {
 			style = common.ActiveTabStyle
 		}
 		tabViews = append(tabViews, style.Render(tab))
 	}
-	modeTabs := lipgloss.JoinHorizontal(lipgloss.Top, tabViews...)
-
-	return lipgloss.JoinVertical(lipgloss.Left, sessionBar, modeTabs)
+	return tabViews
 }
 
+const sessionTabsPrefix = "⚡"
+
 func (m Model) renderSessionTabs() string {
-	filtered := m.filteredSessions()
-	if len(filtered) == 0 {
-		return common.DimSelectedStyle.Render("⚡ No sessions")
+	names, tabs := m.sessionTabParts()
+	if len(names) == 0 {
+		return common.DimSelectedStyle.Render(sessionTabsPrefix + " No sessions")
 	}
+	return strings.Join(append([]string{sessionTabsPrefix}, tabs...), " ")
+}
+
+// sessionTabParts returns the filtered session names and their rendered tabs.
+func (m Model) sessionTabParts() ([]string, []string) {
+	filtered := m.filteredSessions()
 
 	// Get currently selected session from tree
 	selectedSession := m.selectedSession()
@@ -250,9 +339,7 @@ func (m Model) renderSessionTabs() string {
 		selectedName = selectedSession.Name
 	}
 
-	var tabs []string
-	tabs = append(tabs, "⚡")
-
+	var names, tabs []string
 	for _, session := range filtered {
 		name := session.Name
 
@@ -265,20 +352,55 @@ func (m Model) renderSessionTabs() string {
 		if session.Name == selectedName {
 			style = common.ActiveSessionTabStyle
 		}
+		names = append(names, session.Name)
 		tabs = append(tabs, style.Render(name))
 	}
+	return names, tabs
+}
 
-	return strings.Join(tabs, " ")
+func (m Model) renderSendInput() string {
+	label := common.TitleStyle.Render(fmt.Sprintf("Send to %s: ", m.sendTitle))
+	return label + m.sendInput.View()
 }
 
 func (m Model) renderFooter() string {
-	var keys []string
-	if m.tab == TabSessions {
-		keys = []string{"[Enter] attach", "[o] open", "[c] claude", "[x] codex", "[s] shell", "[k] kill", "[/] filter", "[Tab] templates", "[q] quit"}
+	km := m.keys
+	hint := func(a keys.Action, desc string) string {
+		return fmt.Sprintf("[%s] %s", km.Label(a), desc)
+	}
+
+	var hints []string
+	if m.sendActive {
+		quick := []string{hint(keys.SendCtrlC, "ctrl-c"), hint(keys.SendEsc, "esc"), hint(keys.SendYes, "yes"), hint(keys.SendNo, "no")}
+		if m.sendBroadcast {
+			hints = append([]string{"[Enter] send to all"}, quick...)
+		} else {
+			hints = append([]string{"[Enter] send", "[↑/↓] history"}, quick...)
+		}
+		hints = append(hints, "[Esc] close")
+	} else if m.tab == TabSessions && m.markCount() > 0 {
+		hints = []string{
+			fmt.Sprintf("%d marked", m.markCount()),
+			hint(keys.Mark, "mark"), hint(keys.ClosePane, "close"), hint(keys.Restart, "restart"),
+			hint(keys.Rename, "rename"), hint(keys.Send, "broadcast"), hint(keys.KillSession, "kill"),
+			hint(keys.ClearMarks, "clear"),
+		}
+	} else if m.tab == TabSessions {
+		hints = []string{
+			hint(keys.Select, "attach/view"), hint(keys.Send, "send"), hint(keys.Jump, "jump"),
+			hint(keys.Diff, "diff"),
+			hint(keys.OpenSession, "open"), hint(keys.AddClaude, "claude"), hint(keys.AddCodex, "codex"),
+			hint(keys.AddShell, "shell"), hint(keys.KillSession, "kill"), hint(keys.Filter, "filter"),
+			hint(keys.SwitchTab, "templates"), hint(keys.Help, "help"), hint(keys.Quit, "quit"),
+		}
 	} else {
-		keys = []string{"[Enter] apply", "[Tab] sessions", "[q] quit"}
+		hints = []string{
+			hint(keys.Select, "apply"), hint(keys.NewTemplate, "new"), hint(keys.EditTemplate, "edit"),
+			hint(keys.CopyTemplate, "duplicate"), hint(keys.DeleteTemplate, "delete"),
+			hint(keys.SwitchTab, "sessions"), hint(keys.Quit, "quit"),
+		}
 	}
-	return common.FooterStyle.Render(strings.Join(keys, "  "))
+	return common.FooterStyle.Render(strings.Join(hints, "  "))
 }
 
 func (m Model) renderWithDialog() string {
@@ -318,6 +440,12 @@ func (m Model) renderTemplatesList() string {
 		}
 		line := fmt.Sprintf("%s%s", cursor, tmpl.Name)
 		b.WriteString(style.Render(line))
+		switch tmpl.Source {
+		case app.TemplateGlobal:
+			b.WriteString(common.DimSelectedStyle.Render(" *"))
+		case app.TemplateRepo:
+			b.WriteString(common.DimSelectedStyle.Render(" (repo)"))
+		}
 		b.WriteString("\n")
 	}
 
@@ -340,17 +468,55 @@ func (m Model) renderTemplatePreview() string {
 		b.WriteString(tmpl.Description)
 		b.WriteString("\n\n")
 	}
+	switch tmpl.Source {
+	case app.TemplateGlobal:
+		b.WriteString(common.DimSelectedStyle.Render("Defined in the global config"))
+	case app.TemplateRepo:
+		b.WriteString(common.DimSelectedStyle.Render("Defined in the repo's .agentpane.yml"))
+	default:
+		b.WriteString(common.DimSelectedStyle.Render("Built in; editing saves a copy to the global config"))
+	}
+	b.WriteString("\n\n")
 	b.WriteString("Panes:\n")
 	for _, p := range tmpl.Panes {
 		title := p.Type
 		if p.Title != "" {
 			title = fmt.Sprintf("%s (%s)", p.Type, p.Title)
 		}
+		if p.Command != "" {
+			title += common.DimSelectedStyle.Render(" $ " + p.Command)
+		}
 		b.WriteString(fmt.Sprintf("  - %s\n", title))
 	}
 	return b.String()
 }
 
+// paneDirLabel returns the pane's start directory when it differs from the
+// session directory, as happens for workspace sessions.
+func (m Model) paneDirLabel(sessionName string, pane *domain.Pane) string {
+	if pane.Path == "" {
+		return ""
+	}
+	for _, s := range m.snapshot.Sessions {
+		if s.Name == sessionName {
+			if filepath.Clean(s.Path) == filepath.Clean(pane.Path) {
+				return ""
+			}
+			break
+		}
+	}
+	return filepath.Base(pane.Path) + "/"
+}
+
+func (m Model) sessionProfile(name string) string {
+	for _, s := range m.snapshot.Sessions {
+		if s.Name == name {
+			return s.Profile
+		}
+	}
+	return ""
+}
+
 func sessionHasActive(s domain.Session) bool {
 	for _, p := range s.Panes {
 		if p.Status == domain.StatusActive {
@@ -359,3 +525,55 @@ func sessionHasActive(s domain.Session) bool {
 	}
 	return false
 }
+
+// treeWidth is the usable width inside the left panel; longer rows are
+// truncated so each tree item stays on one line.
+func (m Model) treeWidth() int {
+	return m.width/3 - 2
+}
+
+// gitLabel formats a branch with its dirty, ahead and behind counts, e.g.
+// "⎇ main ±3 ↑1 ↓2".
+func gitLabel(g *domain.GitInfo) string {
+	if g == nil {
+		return ""
+	}
+	ref := g.Branch
+	if ref == "" {
+		ref = g.Head
+	}
+	if ref == "" {
+		return ""
+	}
+	parts := []string{"⎇ " + ref}
+	if g.Dirty > 0 {
+		parts = append(parts, fmt.Sprintf("±%d", g.Dirty))
+	}
+	if g.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", g.Ahead))
+	}
+	if g.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", g.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// paneGitLabel labels panes in another worktree or branch than their session,
+// naming the worktree when it differs.
+func (m Model) paneGitLabel(sessionName string, pane *domain.Pane) string {
+	label := gitLabel(pane.Git)
+	if label == "" {
+		return ""
+	}
+	var sessionGit *domain.GitInfo
+	for _, s := range m.snapshot.Sessions {
+		if s.Name == sessionName {
+			sessionGit = s.Git
+			break
+		}
+	}
+	if pane.Git.Root != "" && (sessionGit == nil || sessionGit.Root != pane.Git.Root) {
+		label += " @" + filepath.Base(pane.Git.Root)
+	}
+	return label
+}
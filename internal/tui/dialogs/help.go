@@ -7,9 +7,12 @@ import (
 
 type HelpResult struct{}
 
-type HelpModel struct{}
+type HelpModel struct {
+	bindings string
+}
 
-func NewHelp() HelpModel { return HelpModel{} }
+// NewHelp shows the given key bindings, one "key  description" per line.
+func NewHelp(bindings string) HelpModel { return HelpModel{bindings: bindings} }
 
 func (m HelpModel) Init() tea.Cmd { return nil }
 
@@ -22,22 +25,8 @@ func (m HelpModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m HelpModel) View() string {
-	content := `Keys:
-  ↑/↓         Navigate tree
-  ←/→         Jump between sessions
-  Tab         Switch tab (Sessions/Templates)
-  Enter       Attach to session
-  o           Open new session
-  c           Add Claude pane
-  x           Add Codex pane
-  s           Add Shell pane
-  a           Add pane (dialog)
-  r           Rename pane (when on pane)
-  d           Close pane (when on pane)
-  k           Kill session (when on session)
-  /           Filter sessions
-  ?           Help
-  q           Quit`
+	content := "Keys:\n" + m.bindings +
+		"\nWith items marked, close, restart, rename, send and\nkill act on all of them."
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		Padding(1, 2)
@@ -0,0 +1,84 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MetadataField is the piece of pane or session metadata being edited.
+type MetadataField int
+
+const (
+	FieldTags MetadataField = iota
+	FieldNote
+)
+
+type MetadataResult struct {
+	Cancelled bool
+	Field     MetadataField
+	Value     string
+}
+
+type MetadataModel struct {
+	input textinput.Model
+	field MetadataField
+	label string
+}
+
+// NewEditTags edits a tag list as space-separated words.
+func NewEditTags(target string, tags []string) MetadataModel {
+	ti := textinput.New()
+	ti.Placeholder = "backend auth"
+	ti.SetValue(strings.Join(tags, " "))
+	ti.Focus()
+	return MetadataModel{
+		input: ti,
+		field: FieldTags,
+		label: fmt.Sprintf("Tags for %s (separated by spaces or commas):", target),
+	}
+}
+
+// NewEditNote edits a free-form note; an empty note clears it.
+func NewEditNote(target, note string) MetadataModel {
+	ti := textinput.New()
+	ti.Placeholder = "what is this working on?"
+	ti.CharLimit = 200
+	ti.SetValue(note)
+	ti.Focus()
+	return MetadataModel{
+		input: ti,
+		field: FieldNote,
+		label: fmt.Sprintf("Note for %s:", target),
+	}
+}
+
+func (m MetadataModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m MetadataModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return MetadataResult{Cancelled: true, Field: m.field} }
+		case "enter":
+			return m, func() tea.Msg { return MetadataResult{Field: m.field, Value: m.input.Value()} }
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m MetadataModel) View() string {
+	content := m.label + "\n\n" + m.input.View() + "\n\n[Enter] save  [Esc] cancel"
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1, 2)
+	return style.Render(content)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
 )
 
 type OpenSessionResult struct {
@@ -146,8 +148,8 @@ func (m OpenSessionModel) handleTabCycle() OpenSessionModel {
 }
 
 func (m OpenSessionModel) View() string {
-	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
-	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(common.ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(common.ColorBorder)
 
 	var lines []string
 	lines = append(lines, "Open Session")
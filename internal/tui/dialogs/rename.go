@@ -1,6 +1,8 @@
 package dialogs
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +15,7 @@ type RenameResult struct {
 
 type RenameModel struct {
 	input textinput.Model
+	label string
 }
 
 func NewRename(initial string) RenameModel {
@@ -20,7 +23,19 @@ func NewRename(initial string) RenameModel {
 	ti.Placeholder = "New title"
 	ti.SetValue(initial)
 	ti.Focus()
-	return RenameModel{input: ti}
+	return RenameModel{input: ti, label: "Rename pane:"}
+}
+
+// NewRenamePattern asks for a title pattern applied to several panes.
+func NewRenamePattern(count int) RenameModel {
+	ti := textinput.New()
+	ti.Placeholder = "{{title}}-{{index}}"
+	ti.SetValue("{{title}}")
+	ti.Focus()
+	return RenameModel{
+		input: ti,
+		label: fmt.Sprintf("Rename %d panes ({{title}} = current title, {{index}} = 1, 2, ...):", count),
+	}
 }
 
 func (m RenameModel) Init() tea.Cmd {
@@ -43,7 +58,7 @@ func (m RenameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m RenameModel) View() string {
-	content := "Rename pane:\n\n" + m.input.View() + "\n\n[Enter] save  [Esc] cancel"
+	content := m.label + "\n\n" + m.input.View() + "\n\n[Enter] save  [Esc] cancel"
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		Padding(1, 2)
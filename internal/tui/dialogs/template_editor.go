@@ -0,0 +1,235 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/minghinmatthewlam/agentpane/internal/config"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
+)
+
+type TemplateEditorResult struct {
+	Cancelled bool
+	Name      string
+	// Previous is the name the template was opened with, empty for new
+	// templates and duplicates.
+	Previous string
+	Template config.Template
+}
+
+var paneTypes = []string{"codex", "claude", "shell"}
+
+// Rows before the pane list.
+const (
+	rowName = iota
+	rowDescription
+	rowFirstPane
+)
+
+// TemplateEditorModel edits a template's name, description and panes.
+type TemplateEditorModel struct {
+	heading  string
+	previous string
+	name     string
+	tmpl     config.Template
+
+	cursor  int // row: name, description, then one per pane
+	editing bool
+	input   textinput.Model
+	err     string
+}
+
+// NewTemplateEditor opens a template for editing. previous is the name it is
+// saved under today, or empty when creating a new template.
+func NewTemplateEditor(heading, name, previous string, tmpl config.Template) TemplateEditorModel {
+	ti := textinput.New()
+	ti.CharLimit = 100
+	panes := make([]config.PaneSpec, len(tmpl.Panes))
+	copy(panes, tmpl.Panes)
+	tmpl.Panes = panes
+
+	m := TemplateEditorModel{
+		heading:  heading,
+		previous: previous,
+		name:     name,
+		tmpl:     tmpl,
+		input:    ti,
+	}
+	if name == "" {
+		m.startEdit()
+	}
+	return m
+}
+
+func (m TemplateEditorModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m TemplateEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.editing {
+		return m.updateEditing(key)
+	}
+
+	m.err = ""
+	last := rowFirstPane + len(m.tmpl.Panes) - 1
+	pane := m.cursor - rowFirstPane
+	switch key.String() {
+	case "esc":
+		return m, func() tea.Msg { return TemplateEditorResult{Cancelled: true} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < last {
+			m.cursor++
+		}
+	case "enter", "e":
+		m.startEdit()
+		return m, textinput.Blink
+	case "a":
+		// Add a pane after the cursor, or first when on the header rows
+		at := pane + 1
+		if pane < 0 {
+			at = 0
+		}
+		spec := config.PaneSpec{Type: "claude"}
+		m.tmpl.Panes = append(m.tmpl.Panes[:at], append([]config.PaneSpec{spec}, m.tmpl.Panes[at:]...)...)
+		m.cursor = rowFirstPane + at
+	case "x", "d":
+		if pane >= 0 {
+			m.tmpl.Panes = append(m.tmpl.Panes[:pane], m.tmpl.Panes[pane+1:]...)
+			if m.cursor > rowFirstPane+len(m.tmpl.Panes)-1 {
+				m.cursor--
+			}
+		}
+	case " ", "t":
+		if pane >= 0 {
+			m.tmpl.Panes[pane].Type = nextPaneType(m.tmpl.Panes[pane].Type)
+		}
+	case "K", "shift+up":
+		if pane > 0 {
+			m.tmpl.Panes[pane-1], m.tmpl.Panes[pane] = m.tmpl.Panes[pane], m.tmpl.Panes[pane-1]
+			m.cursor--
+		}
+	case "J", "shift+down":
+		if pane >= 0 && pane < len(m.tmpl.Panes)-1 {
+			m.tmpl.Panes[pane+1], m.tmpl.Panes[pane] = m.tmpl.Panes[pane], m.tmpl.Panes[pane+1]
+			m.cursor++
+		}
+	case "ctrl+s", "s":
+		name := strings.TrimSpace(m.name)
+		if err := config.ValidateTemplate(name, m.tmpl); err != nil {
+			m.err = err.Error()
+			return m, nil
+		}
+		result := TemplateEditorResult{Name: name, Previous: m.previous, Template: m.tmpl}
+		return m, func() tea.Msg { return result }
+	}
+	return m, nil
+}
+
+// startEdit opens the text input on the current row. Pane rows edit the
+// title.
+func (m *TemplateEditorModel) startEdit() {
+	switch m.cursor {
+	case rowName:
+		m.input.Placeholder = "template name"
+		m.input.SetValue(m.name)
+	case rowDescription:
+		m.input.Placeholder = "description"
+		m.input.SetValue(m.tmpl.Description)
+	default:
+		m.input.Placeholder = "title (empty for the default)"
+		m.input.SetValue(m.tmpl.Panes[m.cursor-rowFirstPane].Title)
+	}
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.editing = true
+}
+
+func (m TemplateEditorModel) updateEditing(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "esc":
+		m.editing = false
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		switch m.cursor {
+		case rowName:
+			m.name = value
+		case rowDescription:
+			m.tmpl.Description = value
+		default:
+			m.tmpl.Panes[m.cursor-rowFirstPane].Title = value
+		}
+		m.editing = false
+		m.input.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(key)
+	return m, cmd
+}
+
+func nextPaneType(t string) string {
+	for i, pt := range paneTypes {
+		if pt == t {
+			return paneTypes[(i+1)%len(paneTypes)]
+		}
+	}
+	return paneTypes[0]
+}
+
+func (m TemplateEditorModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.heading + "\n\n")
+
+	row := func(i int, label, value string) {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "→ "
+		}
+		if i == m.cursor && m.editing {
+			value = m.input.View()
+		}
+		b.WriteString(fmt.Sprintf("%s%s%s\n", cursor, label, value))
+	}
+
+	row(rowName, "Name:        ", m.name)
+	row(rowDescription, "Description: ", m.tmpl.Description)
+	b.WriteString("\nPanes:\n")
+	for i, p := range m.tmpl.Panes {
+		title := p.Title
+		if title == "" && !(m.editing && m.cursor == rowFirstPane+i) {
+			title = "(default title)"
+		}
+		row(rowFirstPane+i, fmt.Sprintf("%d. %-7s ", i+1, p.Type), title)
+	}
+	if len(m.tmpl.Panes) == 0 {
+		b.WriteString("  (no panes, press a to add one)\n")
+	}
+
+	if m.err != "" {
+		b.WriteString("\n" + common.ErrorStyle.Render(m.err) + "\n")
+	}
+	if m.editing {
+		b.WriteString("\n[Enter] set  [Esc] cancel")
+	} else {
+		b.WriteString("\n[Enter] edit  [a] add pane  [x] remove  [t] type  [J/K] move  [s] save  [Esc] cancel")
+	}
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1, 2)
+	return style.Render(b.String())
+}
@@ -0,0 +1,335 @@
+// Package diffview is a full-screen review of a working tree's uncommitted
+// changes, with actions to stage, discard and commit them.
+package diffview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+	"github.com/minghinmatthewlam/agentpane/internal/git"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+// CloseMsg is sent when the user leaves the diff view.
+type CloseMsg struct{}
+
+// Model lists changed files on the left and the selected file's diff on the
+// right.
+type Model struct {
+	app   *app.App
+	keys  keys.Map
+	dir   string
+	title string
+
+	// standalone quits the program on close instead of sending CloseMsg
+	standalone bool
+
+	changes []git.Change
+	cursor  int
+	loaded  bool
+
+	viewport viewport.Model
+	diffPath string // file the viewport currently shows
+	width    int
+	height   int
+
+	confirmDiscard bool
+	committing     bool
+	commitInput    textinput.Model
+
+	statusMsg string
+	errMsg    string
+}
+
+func New(a *app.App, km keys.Map, dir, title string, width, height int) Model {
+	ti := textinput.New()
+	ti.Placeholder = "commit message"
+	ti.Prompt = "commit: "
+	ti.CharLimit = 200
+
+	m := Model{
+		app:         a,
+		keys:        km,
+		dir:         dir,
+		title:       title,
+		commitInput: ti,
+	}
+	m.resize(width, height)
+	return m
+}
+
+// Standalone makes the view quit the program when closed, for use outside
+// the dashboard.
+func (m Model) Standalone() Model {
+	m.standalone = true
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadChanges()
+}
+
+type changesMsg struct {
+	changes []git.Change
+	err     error
+}
+
+type diffMsg struct {
+	path    string
+	content string
+	err     error
+}
+
+// actionMsg reports the result of stage, discard or commit.
+type actionMsg struct {
+	status string
+	err    error
+}
+
+func (m Model) loadChanges() tea.Cmd {
+	dir := m.dir
+	return func() tea.Msg {
+		changes, err := m.app.Changes(dir)
+		return changesMsg{changes: changes, err: err}
+	}
+}
+
+func (m Model) loadDiff() tea.Cmd {
+	c := m.selected()
+	if c == nil {
+		return nil
+	}
+	change, dir := *c, m.dir
+	return func() tea.Msg {
+		content, err := m.app.Diff(dir, change)
+		return diffMsg{path: change.Path, content: content, err: err}
+	}
+}
+
+func (m Model) run(status string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		return actionMsg{status: status, err: fn()}
+	}
+}
+
+func (m Model) close() tea.Cmd {
+	if m.standalone {
+		return tea.Quit
+	}
+	return func() tea.Msg { return CloseMsg{} }
+}
+
+func (m Model) selected() *git.Change {
+	if m.cursor < 0 || m.cursor >= len(m.changes) {
+		return nil
+	}
+	return &m.changes[m.cursor]
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		// Reload so lines are truncated to the new width.
+		return m, m.loadDiff()
+	case changesMsg:
+		m.loaded = true
+		if msg.err != nil {
+			m.errMsg = msg.err.Error()
+			return m, nil
+		}
+		m.changes = msg.changes
+		if m.cursor >= len(m.changes) {
+			m.cursor = len(m.changes) - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+		if len(m.changes) == 0 {
+			m.diffPath = ""
+			m.viewport.SetContent("")
+			return m, nil
+		}
+		return m, m.loadDiff()
+	case diffMsg:
+		if c := m.selected(); c == nil || c.Path != msg.path {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.errMsg = msg.err.Error()
+			return m, nil
+		}
+		if msg.path != m.diffPath {
+			m.viewport.GotoTop()
+		}
+		m.diffPath = msg.path
+		m.viewport.SetContent(colorize(msg.content, m.viewport.Width))
+		return m, nil
+	case actionMsg:
+		if msg.err != nil {
+			m.errMsg = msg.err.Error()
+			m.statusMsg = ""
+		} else {
+			m.errMsg = ""
+			m.statusMsg = msg.status
+		}
+		return m, m.loadChanges()
+	case tea.KeyMsg:
+		switch {
+		case m.committing:
+			return m.handleCommitKey(msg)
+		case m.confirmDiscard:
+			return m.handleConfirmKey(msg)
+		}
+		return m.handleKey(msg)
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.viewport.ScrollUp(3)
+		case tea.MouseButtonWheelDown:
+			m.viewport.ScrollDown(3)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key, km := msg.String(), m.keys
+	switch {
+	case km.Matches(key, keys.Quit):
+		return m, m.close()
+	case km.Matches(key, keys.Up):
+		return m.moveCursor(-1)
+	case km.Matches(key, keys.Down):
+		return m.moveCursor(1)
+	case km.Matches(key, keys.Refresh):
+		m.statusMsg = ""
+		m.errMsg = ""
+		return m, m.loadChanges()
+	case km.Matches(key, keys.Stage):
+		c := m.selected()
+		if c == nil {
+			return m, nil
+		}
+		change, dir := *c, m.dir
+		return m, m.run("staged "+change.Path, func() error {
+			return m.app.StageChange(dir, change)
+		})
+	case km.Matches(key, keys.Discard):
+		if m.selected() != nil {
+			m.confirmDiscard = true
+		}
+		return m, nil
+	case km.Matches(key, keys.Commit):
+		m.committing = true
+		m.commitInput.SetValue("")
+		m.commitInput.Focus()
+		return m, textinput.Blink
+	}
+
+	// Scrolling uses the same keys as the pane viewer
+	switch key {
+	case "pgup", "b":
+		m.viewport.PageUp()
+	case "pgdown", "f", " ":
+		m.viewport.PageDown()
+	case "ctrl+u":
+		m.viewport.HalfPageUp()
+	case "ctrl+d":
+		m.viewport.HalfPageDown()
+	case "K":
+		m.viewport.ScrollUp(1)
+	case "J":
+		m.viewport.ScrollDown(1)
+	case "g", "home":
+		m.viewport.GotoTop()
+	case "G", "end":
+		m.viewport.GotoBottom()
+	}
+	return m, nil
+}
+
+func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmDiscard = false
+	c := m.selected()
+	if c == nil || (msg.String() != "y" && msg.String() != "Y") {
+		return m, nil
+	}
+	change, dir := *c, m.dir
+	return m, m.run("discarded "+change.Path, func() error {
+		return m.app.DiscardChange(dir, change)
+	})
+}
+
+func (m Model) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.committing = false
+		m.commitInput.Blur()
+		return m, nil
+	case "enter":
+		message := strings.TrimSpace(m.commitInput.Value())
+		if message == "" {
+			return m, nil
+		}
+		m.committing = false
+		m.commitInput.Blur()
+		dir := m.dir
+		return m, m.run(fmt.Sprintf("committed %q", message), func() error {
+			return m.app.Commit(dir, message)
+		})
+	}
+	var cmd tea.Cmd
+	m.commitInput, cmd = m.commitInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) moveCursor(delta int) (tea.Model, tea.Cmd) {
+	next := m.cursor + delta
+	if next < 0 || next >= len(m.changes) {
+		return m, nil
+	}
+	m.cursor = next
+	return m, m.loadDiff()
+}
+
+func (m *Model) resize(width, height int) {
+	m.width = width
+	m.height = height
+	// Header and footer take one line each.
+	vpHeight := height - 2
+	if vpHeight < 1 {
+		vpHeight = 1
+	}
+	vpWidth := width - m.listWidth() - 1
+	if vpWidth < 1 {
+		vpWidth = 1
+	}
+	if m.viewport.Width == 0 && m.viewport.Height == 0 {
+		m.viewport = viewport.New(vpWidth, vpHeight)
+		return
+	}
+	m.viewport.Width = vpWidth
+	m.viewport.Height = vpHeight
+}
+
+// listWidth is the width of the file list column.
+func (m Model) listWidth() int {
+	w := m.width / 3
+	if w < 20 {
+		w = 20
+	}
+	if w > 60 {
+		w = 60
+	}
+	return w
+}
@@ -0,0 +1,148 @@
+package diffview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/git"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
+	"github.com/minghinmatthewlam/agentpane/internal/tui/keys"
+)
+
+func (m Model) View() string {
+	header := common.TitleStyle.Render(fmt.Sprintf("Changes: %s", m.title))
+	header += common.DimSelectedStyle.Render("  " + m.dir)
+	header = ansi.Truncate(header, m.width, "…")
+
+	var body string
+	switch {
+	case !m.loaded:
+		body = common.DimSelectedStyle.Render("Loading...")
+	case len(m.changes) == 0 && m.errMsg == "":
+		body = common.DimSelectedStyle.Render("No uncommitted changes.")
+	default:
+		list := lipgloss.NewStyle().
+			Width(m.listWidth()).
+			Height(m.viewport.Height).
+			MaxHeight(m.viewport.Height).
+			Render(m.renderList())
+		sep := lipgloss.NewStyle().Foreground(common.ColorBorder).
+			Render(strings.TrimSuffix(strings.Repeat("│\n", m.viewport.Height), "\n"))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, list, sep, m.viewport.View())
+	}
+	body = lipgloss.NewStyle().Height(m.height - 2).MaxHeight(m.height - 2).Render(body)
+
+	var footer string
+	switch {
+	case m.committing:
+		footer = m.commitInput.View()
+	case m.confirmDiscard:
+		footer = common.ErrorStyle.Render(fmt.Sprintf("Discard all changes to %s? [y/N]", m.selected().Path))
+	case m.errMsg != "":
+		footer = common.ErrorStyle.Render("Error: " + m.errMsg)
+	case m.statusMsg != "":
+		footer = common.StatusStyle.Render(m.statusMsg)
+	default:
+		km := m.keys
+		footer = common.DimSelectedStyle.Render(fmt.Sprintf("[%s/%s] file  [PgUp/PgDn] scroll  [%s] stage  [%s] discard  [%s] commit  [%s] refresh  [%s] back",
+			km.Label(keys.Up), km.Label(keys.Down), km.Label(keys.Stage), km.Label(keys.Discard),
+			km.Label(keys.Commit), km.Label(keys.Refresh), km.Label(keys.Quit)))
+	}
+
+	footer = ansi.Truncate(footer, m.width, "…")
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// renderList shows one row per changed file, scrolled to keep the cursor in
+// view.
+func (m Model) renderList() string {
+	height := m.viewport.Height
+	start := 0
+	if m.cursor >= height {
+		start = m.cursor - height + 1
+	}
+
+	var rows []string
+	for i := start; i < len(m.changes) && i < start+height; i++ {
+		c := m.changes[i]
+		cursor := "  "
+		style := common.NormalStyle
+		if i == m.cursor {
+			cursor = "→ "
+			style = common.SelectedStyle
+		}
+		name := c.Path
+		if c.OrigPath != "" {
+			name = c.OrigPath + " → " + c.Path
+		}
+		row := cursor + statusLabel(c) + " " + style.Render(name)
+		rows = append(rows, ansi.Truncate(row, m.listWidth(), "…"))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// statusLabel is a two-letter code like git status -s: index state, then work
+// tree state.
+func statusLabel(c git.Change) string {
+	if c.Untracked {
+		return lipgloss.NewStyle().Foreground(common.ColorWarning).Render("??")
+	}
+	index, tree := " ", " "
+	switch {
+	case c.Added:
+		index = "A"
+	case c.OrigPath != "":
+		index = "R"
+	case c.Deleted && c.Staged:
+		index = "D"
+	case c.Staged:
+		index = "M"
+	}
+	switch {
+	case c.Deleted && c.Unstaged:
+		tree = "D"
+	case c.Unstaged:
+		tree = "M"
+	}
+	return lipgloss.NewStyle().Foreground(common.ColorSuccess).Render(index) +
+		lipgloss.NewStyle().Foreground(common.ColorError).Render(tree)
+}
+
+// colorize styles a unified diff: file headers bold, hunk headers in the
+// primary color, additions green and removals red. Lines are truncated to
+// width since the viewport does not wrap.
+func colorize(diff string, width int) string {
+	var (
+		header  = lipgloss.NewStyle().Bold(true)
+		hunk    = lipgloss.NewStyle().Foreground(common.ColorPrimary)
+		added   = lipgloss.NewStyle().Foreground(common.ColorSuccess)
+		removed = lipgloss.NewStyle().Foreground(common.ColorError)
+		meta    = lipgloss.NewStyle().Foreground(common.ColorSecondary)
+	)
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		line = ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), width, "…")
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "new file"):
+			line = header.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = hunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = removed.Render(line)
+		case strings.HasPrefix(line, "index "), strings.HasPrefix(line, "\\"),
+			strings.HasPrefix(line, "deleted file"), strings.HasPrefix(line, "similarity"),
+			strings.HasPrefix(line, "rename "):
+			line = meta.Render(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,311 @@
+// Package keys maps dashboard actions to the keys that trigger them.
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Action is a dashboard operation that can be bound to keys. The string
+// value is the name used under ui.keys in the config.
+type Action string
+
+const (
+	Up             Action = "up"
+	Down           Action = "down"
+	PrevSession    Action = "prev_session"
+	NextSession    Action = "next_session"
+	SwitchTab      Action = "switch_tab"
+	Select         Action = "select"
+	View           Action = "view"
+	Send           Action = "send"
+	Diff           Action = "diff"
+	Jump           Action = "jump"
+	JumpZoom       Action = "jump_zoom"
+	OpenSession    Action = "open_session"
+	AddClaude      Action = "add_claude"
+	AddCodex       Action = "add_codex"
+	AddShell       Action = "add_shell"
+	AddPane        Action = "add_pane"
+	Rename         Action = "rename"
+	Tags           Action = "tags"
+	Note           Action = "note"
+	Restart        Action = "restart"
+	ClosePane      Action = "close_pane"
+	KillSession    Action = "kill_session"
+	Mark           Action = "mark"
+	ClearMarks     Action = "clear_marks"
+	NewTemplate    Action = "new_template"
+	EditTemplate   Action = "edit_template"
+	CopyTemplate   Action = "duplicate_template"
+	DeleteTemplate Action = "delete_template"
+	Filter         Action = "filter"
+	Sort           Action = "sort"
+	Group          Action = "group"
+	Pin            Action = "pin"
+	Stage          Action = "stage"
+	Discard        Action = "discard"
+	Commit         Action = "commit"
+	Refresh        Action = "refresh"
+	SendCtrlC      Action = "send_ctrl_c"
+	SendEsc        Action = "send_esc"
+	SendYes        Action = "send_yes"
+	SendNo         Action = "send_no"
+	Help           Action = "help"
+	Quit           Action = "quit"
+)
+
+// Scope is where an action's keys apply. Actions in scopes that are never
+// active at the same time may share keys.
+type Scope string
+
+const (
+	// ScopeDashboard actions work on both tabs, and navigation and quit
+	// also in the diff view.
+	ScopeDashboard Scope = "dashboard"
+	ScopeSessions  Scope = "sessions"
+	ScopeTemplates Scope = "templates"
+	ScopeDiff      Scope = "diff"
+	ScopeSend      Scope = "send"
+)
+
+// overlaps reports whether keys of the two scopes can be pressed in the same
+// place and so must not collide.
+func overlaps(a, b Scope) bool {
+	if a == b {
+		return true
+	}
+	if a == ScopeSend || b == ScopeSend {
+		return false
+	}
+	return a == ScopeDashboard || b == ScopeDashboard
+}
+
+// Binding is one action with its keys, help text and scope.
+type Binding struct {
+	Action Action
+	Keys   []string
+	Help   string
+	Scope  Scope
+}
+
+// defaults lists every action in the order shown by help.
+var defaults = []Binding{
+	{Up, []string{"up", "k"}, "Move up", ScopeDashboard},
+	{Down, []string{"down", "j"}, "Move down", ScopeDashboard},
+	{PrevSession, []string{"left", "h"}, "Previous session", ScopeSessions},
+	{NextSession, []string{"right", "l"}, "Next session", ScopeSessions},
+	{SwitchTab, []string{"tab"}, "Switch tab (Sessions/Templates)", ScopeDashboard},
+	{Select, []string{"enter"}, "Attach to session / view pane / apply template", ScopeDashboard},
+	{View, []string{"v"}, "View pane output (scrollback)", ScopeSessions},
+	{Send, []string{"i"}, "Send input to pane", ScopeSessions},
+	{Diff, []string{"D"}, "Review git changes in pane's directory", ScopeSessions},
+	{Jump, []string{"f"}, "Jump to pane", ScopeSessions},
+	{JumpZoom, []string{"F"}, "Jump to pane and zoom it", ScopeSessions},
+	{OpenSession, []string{"o"}, "Open new session", ScopeDashboard},
+	{AddClaude, []string{"c"}, "Add Claude pane", ScopeSessions},
+	{AddCodex, []string{"x"}, "Add Codex pane", ScopeSessions},
+	{AddShell, []string{"s"}, "Add Shell pane", ScopeSessions},
+	{AddPane, []string{"a"}, "Add pane (dialog)", ScopeSessions},
+	{Rename, []string{"r"}, "Rename pane", ScopeSessions},
+	{Tags, []string{"t"}, "Edit tags of pane/session", ScopeSessions},
+	{Note, []string{"N"}, "Edit note of pane/session", ScopeSessions},
+	{Restart, []string{"R"}, "Restart pane", ScopeSessions},
+	{ClosePane, []string{"d"}, "Close pane", ScopeSessions},
+	{KillSession, []string{"K"}, "Kill session", ScopeSessions},
+	{Mark, []string{"space"}, "Mark pane/session for bulk actions", ScopeSessions},
+	{ClearMarks, []string{"esc"}, "Clear marks", ScopeSessions},
+	{NewTemplate, []string{"n"}, "New template (Templates tab)", ScopeTemplates},
+	{EditTemplate, []string{"e"}, "Edit template (Templates tab)", ScopeTemplates},
+	{CopyTemplate, []string{"c"}, "Duplicate template (Templates tab)", ScopeTemplates},
+	{DeleteTemplate, []string{"d"}, "Delete template (Templates tab)", ScopeTemplates},
+	{Filter, []string{"/"}, "Filter by session, pane, path, type, tag or note", ScopeSessions},
+	{Sort, []string{"S"}, "Cycle sort: name, activity, attached, attention", ScopeSessions},
+	{Group, []string{"G"}, "Cycle grouping: none, directory, git remote", ScopeSessions},
+	{Pin, []string{"p"}, "Pin/unpin session (pinned sort first)", ScopeSessions},
+	{Stage, []string{"s"}, "Stage file (diff view)", ScopeDiff},
+	{Discard, []string{"d"}, "Discard file changes (diff view)", ScopeDiff},
+	{Commit, []string{"c"}, "Commit staged changes (diff view)", ScopeDiff},
+	{Refresh, []string{"r"}, "Reload changes (diff view)", ScopeDiff},
+	{SendCtrlC, []string{"ctrl+c"}, "Send Ctrl-C (send box)", ScopeSend},
+	{SendEsc, []string{"ctrl+g"}, "Send Esc (send box)", ScopeSend},
+	{SendYes, []string{"ctrl+y"}, "Answer y (send box)", ScopeSend},
+	{SendNo, []string{"ctrl+n"}, "Answer n (send box)", ScopeSend},
+	{Help, []string{"?"}, "Help", ScopeDashboard},
+	{Quit, []string{"q", "esc"}, "Quit", ScopeDashboard},
+}
+
+// Map resolves keys to actions.
+type Map struct {
+	bindings []Binding
+	byAction map[Action][]string
+}
+
+// Default returns the built-in bindings.
+func Default() Map {
+	m, _ := New(nil)
+	return m
+}
+
+// claim is an overridden action holding a key.
+type claim struct {
+	action Action
+	scope  Scope
+}
+
+// New builds a map from the defaults with overrides applied. An override
+// replaces an action's keys; keys it claims are removed from actions in
+// overlapping scopes that were not overridden so the two don't collide.
+func New(overrides map[string][]string) (Map, error) {
+	scopes := make(map[Action]Scope, len(defaults))
+	for _, b := range defaults {
+		scopes[b.Action] = b.Scope
+	}
+
+	claimed := map[string][]claim{}
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a := Action(name)
+		scope, ok := scopes[a]
+		if !ok {
+			return Map{}, fmt.Errorf("ui.keys: unknown action %q (expected one of %s)", name, strings.Join(ActionNames(), ", "))
+		}
+		for _, k := range overrides[name] {
+			k = normalize(k)
+			for _, c := range claimed[k] {
+				if overlaps(c.scope, scope) {
+					return Map{}, fmt.Errorf("ui.keys: %q is bound to both %s and %s", k, c.action, a)
+				}
+			}
+			claimed[k] = append(claimed[k], claim{action: a, scope: scope})
+		}
+	}
+
+	m := Map{byAction: make(map[Action][]string, len(defaults))}
+	for _, b := range defaults {
+		var keys []string
+		if custom, ok := overrides[string(b.Action)]; ok {
+			for _, k := range custom {
+				keys = append(keys, normalize(k))
+			}
+		} else {
+			for _, k := range b.Keys {
+				k = normalize(k)
+				if !taken(claimed[k], b.Scope) {
+					keys = append(keys, k)
+				}
+			}
+		}
+		b.Keys = keys
+		m.bindings = append(m.bindings, b)
+		m.byAction[b.Action] = keys
+	}
+	return m, nil
+}
+
+// taken reports whether an override in a scope overlapping scope holds the key.
+func taken(claims []claim, scope Scope) bool {
+	for _, c := range claims {
+		if overlaps(c.scope, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeOf returns the scope an action's keys apply in.
+func ScopeOf(a Action) Scope {
+	for _, b := range defaults {
+		if b.Action == a {
+			return b.Scope
+		}
+	}
+	return ScopeDashboard
+}
+
+// Matches reports whether key (a tea.KeyMsg string) triggers the action.
+func (m Map) Matches(key string, a Action) bool {
+	for _, k := range m.byAction[a] {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Label is the display form of the action's first key, for footers.
+func (m Map) Label(a Action) string {
+	keys := m.byAction[a]
+	if len(keys) == 0 {
+		return "-"
+	}
+	return display(keys[0])
+}
+
+// HelpText lists every bound action, one per line.
+func (m Map) HelpText() string {
+	var b strings.Builder
+	for _, binding := range m.bindings {
+		if len(binding.Keys) == 0 {
+			continue
+		}
+		labels := make([]string, len(binding.Keys))
+		for i, k := range binding.Keys {
+			labels[i] = display(k)
+		}
+		fmt.Fprintf(&b, "  %-12s%s\n", strings.Join(labels, ", "), binding.Help)
+	}
+	return b.String()
+}
+
+// ActionNames returns every action name, for config errors and docs.
+func ActionNames() []string {
+	out := make([]string, len(defaults))
+	for i, b := range defaults {
+		out[i] = string(b.Action)
+	}
+	return out
+}
+
+// normalize maps config spellings to tea.KeyMsg strings.
+func normalize(k string) string {
+	switch strings.ToLower(k) {
+	case "space":
+		return " "
+	case "return":
+		return "enter"
+	case "escape":
+		return "esc"
+	}
+	return k
+}
+
+func display(k string) string {
+	switch k {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "enter":
+		return "Enter"
+	case "tab":
+		return "Tab"
+	case "esc":
+		return "Esc"
+	}
+	if rest, ok := strings.CutPrefix(k, "ctrl+"); ok && len(rest) == 1 {
+		return "^" + strings.ToUpper(rest)
+	}
+	return k
+}
@@ -0,0 +1,87 @@
+package keys
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOverrideOnlyUnbindsOverlappingScopes(t *testing.T) {
+	m, err := New(map[string][]string{"close_pane": {"x"}, "stage": {"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		action Action
+		want   []string
+	}{
+		{ClosePane, []string{"x"}},
+		{AddCodex, nil},                 // lost x to close_pane on the same tab
+		{DeleteTemplate, []string{"d"}}, // other tab keeps d
+		{Discard, []string{"d"}},        // diff view keeps d
+		{Stage, []string{"a"}},
+		{AddPane, []string{"a"}}, // stage only applies in the diff view
+		{Quit, []string{"q", "esc"}},
+		{SendCtrlC, []string{"ctrl+c"}},
+		{PrevSession, []string{"left", "h"}},
+	}
+	for _, c := range cases {
+		if got := m.byAction[c.action]; !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s keys = %v, want %v", c.action, got, c.want)
+		}
+	}
+}
+
+func TestOverrideConflicts(t *testing.T) {
+	cases := []struct {
+		name      string
+		overrides map[string][]string
+		conflict  bool
+	}{
+		{"same key on two tabs", map[string][]string{"close_pane": {"z"}, "delete_template": {"z"}}, false},
+		{"same key in diff view and sessions tab", map[string][]string{"add_shell": {"z"}, "stage": {"z"}}, false},
+		{"send box key shadowing quit", map[string][]string{"send_yes": {"q"}, "quit": {"q"}}, false},
+		{"same key on one tab", map[string][]string{"close_pane": {"z"}, "add_shell": {"z"}}, true},
+		{"dashboard key and a tab key", map[string][]string{"quit": {"z"}, "delete_template": {"z"}}, true},
+		{"dashboard key and a diff key", map[string][]string{"up": {"z"}, "commit": {"z"}}, true},
+	}
+	for _, c := range cases {
+		_, err := New(c.overrides)
+		if got := err != nil; got != c.conflict {
+			t.Errorf("%s: conflict = %v (%v), want %v", c.name, got, err, c.conflict)
+		}
+		if err != nil && !strings.Contains(err.Error(), "bound to both") {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
+
+func TestNewRejectsUnknownAction(t *testing.T) {
+	if _, err := New(map[string][]string{"launch_rockets": {"L"}}); err == nil || !strings.Contains(err.Error(), "unknown action") {
+		t.Fatalf("expected unknown action error, got %v", err)
+	}
+}
+
+func TestMatchesAndLabel(t *testing.T) {
+	m, err := New(map[string][]string{"mark": {"space"}, "kill_session": {"ctrl+k"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Matches(" ", Mark) || m.Matches("space", Mark) {
+		t.Error("space should be normalized to the key message string")
+	}
+	for a, want := range map[Action]string{Mark: "Space", KillSession: "^K", Up: "↑", SendYes: "^Y"} {
+		if got := m.Label(a); got != want {
+			t.Errorf("Label(%s) = %q, want %q", a, got, want)
+		}
+	}
+}
+
+func TestDefaultsHaveScopes(t *testing.T) {
+	for _, b := range defaults {
+		if b.Scope == "" {
+			t.Errorf("%s has no scope", b.Action)
+		}
+	}
+}
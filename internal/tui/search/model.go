@@ -14,6 +14,11 @@ type Model struct {
 	input   textinput.Model
 	results []app.SearchResult
 	errMsg  string
+
+	// query the current results were produced for
+	lastQuery string
+	cursor    int
+	selected  *app.SearchResult
 }
 
 func NewModel(a *app.App) Model {
@@ -31,17 +36,41 @@ func (m Model) Init() tea.Cmd {
 }
 
 type resultsMsg struct {
+	query   string
 	results []app.SearchResult
 	err     error
 }
 
+// Selected returns the result chosen with Enter, or nil if the search was
+// closed without picking one.
+func (m Model) Selected() *app.SearchResult {
+	return m.selected
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc", "q":
 			return m, tea.Quit
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+			return m, nil
 		case "enter":
+			// A second Enter on unchanged input jumps to the highlighted result
+			query := strings.TrimSpace(m.input.Value())
+			if query == m.lastQuery && m.cursor < len(m.results) {
+				r := m.results[m.cursor]
+				m.selected = &r
+				return m, tea.Quit
+			}
 			return m, m.searchCmd()
 		}
 	case resultsMsg:
@@ -50,6 +79,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.errMsg = ""
 			m.results = msg.results
+			m.lastQuery = msg.query
+			m.cursor = 0
 		}
 		return m, nil
 	}
@@ -63,6 +94,6 @@ func (m Model) searchCmd() tea.Cmd {
 	query := strings.TrimSpace(m.input.Value())
 	return func() tea.Msg {
 		results, err := m.app.Search(query)
-		return resultsMsg{results: results, err: err}
+		return resultsMsg{query: query, results: results, err: err}
 	}
 }
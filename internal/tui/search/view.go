@@ -11,19 +11,29 @@ func (m Model) View() string {
 	var b strings.Builder
 	b.WriteString("Search\n\n")
 	b.WriteString(m.input.View())
-	b.WriteString("\n\n[Enter] search  [Esc] close\n\n")
+	b.WriteString("\n\n[Enter] search, again to jump  [↑/↓] select  [Esc] close\n\n")
 
 	if m.errMsg != "" {
 		b.WriteString("Error: " + m.errMsg + "\n")
 	} else if len(m.results) == 0 {
 		b.WriteString("No results\n")
 	} else {
-		for _, r := range m.results {
+		for i, r := range m.results {
+			prefix := "  "
+			if i == m.cursor {
+				prefix = "> "
+			}
+			line := fmt.Sprintf("%sPane: %s (%s) in %s", prefix, r.Title, r.Type, r.Session)
 			if r.PaneID == "" {
-				b.WriteString(fmt.Sprintf("Session: %s\n", r.Session))
-			} else {
-				b.WriteString(fmt.Sprintf("Pane: %s (%s) in %s\n", r.Title, r.Type, r.Session))
+				line = fmt.Sprintf("%sSession: %s", prefix, r.Session)
+			}
+			for _, tag := range r.Tags {
+				line += " #" + tag
+			}
+			if r.Note != "" {
+				line += " · " + r.Note
 			}
+			b.WriteString(line + "\n")
 		}
 	}
 
@@ -0,0 +1,279 @@
+package viewer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/app"
+)
+
+// CloseMsg is sent when the user leaves the viewer.
+type CloseMsg struct{}
+
+// Model is a full-screen, scrollable view of a pane's history. It follows
+// live output while scrolled to the bottom.
+type Model struct {
+	app    *app.App
+	paneID string
+	title  string
+
+	viewport viewport.Model
+	width    int
+	height   int
+
+	lines  []string // raw lines, ANSI preserved
+	plain  []string // lines with ANSI stripped, for searching
+	follow bool
+	loaded bool
+
+	searchInput textinput.Model
+	searching   bool
+	query       string
+	matches     []int // line indexes matching query
+	matchIndex  int
+
+	errMsg string
+}
+
+func New(a *app.App, paneID, title string, width, height int) Model {
+	ti := textinput.New()
+	ti.Placeholder = "search"
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+
+	m := Model{
+		app:         a,
+		paneID:      paneID,
+		title:       title,
+		follow:      true,
+		searchInput: ti,
+	}
+	m.resize(width, height)
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.capture()
+}
+
+type contentMsg struct {
+	paneID  string
+	content string
+	err     error
+}
+
+type tickMsg struct {
+	paneID string
+}
+
+func (m Model) capture() tea.Cmd {
+	paneID := m.paneID
+	return func() tea.Msg {
+		content, err := m.app.CapturePaneHistory(paneID)
+		return contentMsg{paneID: paneID, content: content, err: err}
+	}
+}
+
+func (m Model) scheduleRefresh() tea.Cmd {
+	paneID := m.paneID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tickMsg{paneID: paneID}
+	})
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		return m, nil
+	case contentMsg:
+		if msg.paneID != m.paneID {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.errMsg = msg.err.Error()
+			return m, m.scheduleRefresh()
+		}
+		m.errMsg = ""
+		m.setContent(msg.content)
+		return m, m.scheduleRefresh()
+	case tickMsg:
+		if msg.paneID != m.paneID {
+			return m, nil
+		}
+		return m, m.capture()
+	case tea.KeyMsg:
+		if m.searching {
+			return m.handleSearchKey(msg)
+		}
+		return m.handleKey(msg)
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.ScrollBy(-3)
+		case tea.MouseButtonWheelDown:
+			m.ScrollBy(3)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, func() tea.Msg { return CloseMsg{} }
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.matches = nil
+			m.render()
+			return m, nil
+		}
+		return m, func() tea.Msg { return CloseMsg{} }
+	case "/":
+		m.searching = true
+		m.searchInput.SetValue(m.query)
+		m.searchInput.CursorEnd()
+		m.searchInput.Focus()
+		return m, textinput.Blink
+	case "n":
+		m.jumpMatch(1)
+		return m, nil
+	case "N":
+		m.jumpMatch(-1)
+		return m, nil
+	case "g", "home":
+		m.viewport.GotoTop()
+	case "G", "end":
+		m.viewport.GotoBottom()
+	case "up", "k":
+		m.viewport.ScrollUp(1)
+	case "down", "j":
+		m.viewport.ScrollDown(1)
+	case "pgup", "b":
+		m.viewport.PageUp()
+	case "pgdown", "f", " ":
+		m.viewport.PageDown()
+	case "ctrl+u":
+		m.viewport.HalfPageUp()
+	case "ctrl+d":
+		m.viewport.HalfPageDown()
+	}
+	m.follow = m.viewport.AtBottom()
+	return m, nil
+}
+
+func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.searchInput.Blur()
+		m.query = strings.TrimSpace(m.searchInput.Value())
+		m.render()
+		m.matchIndex = -1
+		m.jumpMatch(1)
+		return m, nil
+	case "esc":
+		m.searching = false
+		m.searchInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
+// ScrollBy scrolls the view by n lines (negative scrolls up).
+func (m *Model) ScrollBy(n int) {
+	if n < 0 {
+		m.viewport.ScrollUp(-n)
+	} else {
+		m.viewport.ScrollDown(n)
+	}
+	m.follow = m.viewport.AtBottom()
+}
+
+func (m *Model) resize(width, height int) {
+	m.width = width
+	m.height = height
+	// Header and footer take one line each.
+	vpHeight := height - 2
+	if vpHeight < 1 {
+		vpHeight = 1
+	}
+	if !m.loaded {
+		m.viewport = viewport.New(width, vpHeight)
+		return
+	}
+	m.viewport.Width = width
+	m.viewport.Height = vpHeight
+	m.render()
+	if m.follow {
+		m.viewport.GotoBottom()
+	}
+}
+
+func (m *Model) setContent(content string) {
+	content = strings.TrimRight(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	m.lines = strings.Split(content, "\n")
+	m.plain = make([]string, len(m.lines))
+	for i, l := range m.lines {
+		m.plain[i] = ansi.Strip(l)
+	}
+	m.loaded = true
+	m.render()
+	if m.follow {
+		m.viewport.GotoBottom()
+	}
+}
+
+// render rebuilds the viewport content, highlighting search matches.
+func (m *Model) render() {
+	m.matches = nil
+	if m.query == "" {
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		return
+	}
+
+	re := searchPattern(m.query)
+	out := make([]string, len(m.lines))
+	for i, line := range m.lines {
+		plain := m.plain[i]
+		if !re.MatchString(plain) {
+			out[i] = line
+			continue
+		}
+		m.matches = append(m.matches, i)
+		out[i] = highlight(line, plain, re)
+	}
+	m.viewport.SetContent(strings.Join(out, "\n"))
+}
+
+func (m *Model) jumpMatch(dir int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	if m.matchIndex < 0 || m.matchIndex >= len(m.matches) {
+		// Start from the first match at or below the current position.
+		m.matchIndex = 0
+		for i, line := range m.matches {
+			if line >= m.viewport.YOffset {
+				m.matchIndex = i
+				break
+			}
+		}
+	} else {
+		m.matchIndex = (m.matchIndex + dir + len(m.matches)) % len(m.matches)
+	}
+	m.viewport.SetYOffset(m.matches[m.matchIndex] - m.viewport.Height/2)
+	m.follow = m.viewport.AtBottom()
+}
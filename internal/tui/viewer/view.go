@@ -0,0 +1,109 @@
+package viewer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/minghinmatthewlam/agentpane/internal/tui/common"
+)
+
+// Matches are shown in reverse video, which reads on any color scheme and
+// can be switched off again without touching the line's own colors.
+const (
+	matchOn  = "\x1b[7m"
+	matchOff = "\x1b[27m"
+)
+
+func (m Model) View() string {
+	header := common.TitleStyle.Render(fmt.Sprintf("%s (%s)", m.title, m.paneID))
+	if m.follow {
+		header += common.DimSelectedStyle.Render("  following")
+	} else {
+		header += common.DimSelectedStyle.Render(fmt.Sprintf("  %3.0f%%", m.viewport.ScrollPercent()*100))
+	}
+
+	body := m.viewport.View()
+	if !m.loaded {
+		body = common.DimSelectedStyle.Render("Loading...")
+	}
+
+	var footer string
+	switch {
+	case m.errMsg != "":
+		footer = common.ErrorStyle.Render("Error: " + m.errMsg)
+	case m.searching:
+		footer = m.searchInput.View()
+	case m.query != "":
+		pos := 0
+		if m.matchIndex >= 0 && m.matchIndex < len(m.matches) {
+			pos = m.matchIndex + 1
+		}
+		footer = common.DimSelectedStyle.Render(fmt.Sprintf("/%s  %d/%d  [n/N] next/prev  [Esc] clear", m.query, pos, len(m.matches)))
+	default:
+		footer = common.DimSelectedStyle.Render("[↑/↓] scroll  [PgUp/PgDn] page  [g/G] top/bottom  [/] search  [q] back")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// searchPattern matches query literally, ignoring case.
+func searchPattern(query string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+}
+
+// highlight marks every match of re in line, keeping its escape sequences.
+// plain is line without them: matching runs on plain, and the match offsets
+// are carried over to line by walking both together. Matching runs on the
+// text itself rather than a lowercased copy, since lowercasing can change the
+// byte length of some characters.
+func highlight(line, plain string, re *regexp.Regexp) string {
+	locs := re.FindAllStringIndex(plain, -1)
+	if len(locs) == 0 {
+		return line
+	}
+
+	var b strings.Builder
+	pos, k, on := 0, 0, false
+	var state byte
+	for rest := line; len(rest) > 0; {
+		seq, _, n, newState := ansi.DecodeSequence(rest, state, nil)
+		state = newState
+		rest = rest[n:]
+		if len(seq) > 0 && seq[0] == ansi.ESC {
+			b.WriteString(seq)
+			if on {
+				// The sequence may have been a reset; put the highlight back.
+				b.WriteString(matchOn)
+			}
+			continue
+		}
+		for len(seq) > 0 {
+			if !on && k < len(locs) && pos >= locs[k][0] {
+				b.WriteString(matchOn)
+				on = true
+			}
+			_, size := utf8.DecodeRuneInString(seq)
+			b.WriteString(seq[:size])
+			seq = seq[size:]
+			pos += size
+			if on && pos >= locs[k][1] {
+				b.WriteString(matchOff)
+				on = false
+				k++
+			}
+		}
+	}
+	if pos != len(plain) {
+		// The walk lost track of the plain text; show it without colors
+		// rather than highlight the wrong characters.
+		return re.ReplaceAllStringFunc(plain, func(m string) string {
+			return matchOn + m + matchOff
+		})
+	}
+	return b.String()
+}
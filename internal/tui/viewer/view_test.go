@@ -0,0 +1,68 @@
+package viewer
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		line, query string
+		matches     int
+	}{
+		{"Error: build failed, error again", "error", 2},
+		{"ȺȺȺȺx", "x", 1},
+		{"ȺȺȺȺx", "ⱥ", 4},
+		{"İstanbul istanbul", "stan", 2},
+		{"a.b axb", ".", 1},
+		{"nothing here", "zzz", 0},
+	}
+	for _, c := range cases {
+		re := searchPattern(c.query)
+		got := highlight(c.line, c.line, re)
+		if plain := ansi.Strip(got); plain != c.line {
+			t.Errorf("highlight(%q, %q) changed the text to %q", c.line, c.query, plain)
+		}
+		if n := len(re.FindAllStringIndex(c.line, -1)); n != c.matches {
+			t.Errorf("searchPattern(%q) found %d matches in %q, want %d", c.query, n, c.line, c.matches)
+		}
+	}
+}
+
+func TestHighlightKeepsStyles(t *testing.T) {
+	cases := []struct {
+		name, line, query, want string
+	}{
+		{
+			name:  "match inside a colored span",
+			line:  "\x1b[31mError: failed\x1b[0m done",
+			query: "fail",
+			want:  "\x1b[31mError: \x1b[7mfail\x1b[27med\x1b[0m done",
+		},
+		{
+			name:  "reset inside the match",
+			line:  "\x1b[1mbui\x1b[0mld ok",
+			query: "build",
+			want:  "\x1b[1m\x1b[7mbui\x1b[0m\x1b[7mld\x1b[27m ok",
+		},
+		{
+			name:  "several matches across styles",
+			line:  "\x1b[32mok\x1b[0m \x1b[33mOK\x1b[0m",
+			query: "ok",
+			want:  "\x1b[32m\x1b[7mok\x1b[27m\x1b[0m \x1b[33m\x1b[7mOK\x1b[27m\x1b[0m",
+		},
+		{
+			name:  "multibyte text",
+			line:  "\x1b[36mȺȺx\x1b[0m",
+			query: "ⱥx",
+			want:  "\x1b[36mȺ\x1b[7mȺx\x1b[27m\x1b[0m",
+		},
+	}
+	for _, c := range cases {
+		plain := ansi.Strip(c.line)
+		if got := highlight(c.line, plain, searchPattern(c.query)); got != c.want {
+			t.Errorf("%s: highlight = %q, want %q", c.name, got, c.want)
+		}
+	}
+}